@@ -0,0 +1,82 @@
+package batch
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+)
+
+// checkpoint is an append-only file holding one JSON encoded key per line
+// for every record that was processed Ok.
+type checkpoint struct {
+	file    *os.File
+	writer  *bufio.Writer
+	done    map[string]struct{}
+	pending int
+	every   int
+}
+
+func openCheckpoint(path string, every int) (*checkpoint, error) {
+	done := map[string]struct{}{}
+	existing, err := os.Open(path)
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+	case err != nil:
+		return nil, fmt.Errorf("open checkpoint: %w", err)
+	default:
+		scanner := bufio.NewScanner(existing)
+		for scanner.Scan() {
+			var key string
+			if err := json.Unmarshal(scanner.Bytes(), &key); err != nil {
+				// a torn final line from a crash mid-write; the record is simply reprocessed
+				continue
+			}
+			done[key] = struct{}{}
+		}
+		_ = existing.Close()
+		if err := scanner.Err(); err != nil {
+			return nil, fmt.Errorf("read checkpoint: %w", err)
+		}
+	}
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("open checkpoint: %w", err)
+	}
+	return &checkpoint{file: file, writer: bufio.NewWriter(file), done: done, every: every}, nil
+}
+
+func (c *checkpoint) contains(key string) bool {
+	_, ok := c.done[key]
+	return ok
+}
+
+func (c *checkpoint) record(key string) error {
+	line, err := json.Marshal(key)
+	if err != nil {
+		return err
+	}
+	if _, err := c.writer.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("write checkpoint: %w", err)
+	}
+	c.done[key] = struct{}{}
+	c.pending++
+	if c.pending >= c.every {
+		return c.flush()
+	}
+	return nil
+}
+
+func (c *checkpoint) flush() error {
+	c.pending = 0
+	if err := c.writer.Flush(); err != nil {
+		return fmt.Errorf("write checkpoint: %w", err)
+	}
+	return nil
+}
+
+func (c *checkpoint) close() error {
+	return errors.Join(c.flush(), c.file.Close())
+}
@@ -0,0 +1,20 @@
+package batch
+
+import "context"
+
+// DeadLetterSink receives records that failed processing.
+type DeadLetterSink[T any] interface {
+	// Send hands over a failed record together with the error it failed with.
+	Send(ctx context.Context, record T, err error) error
+}
+
+// DeadLetterFunc adapts a function to a DeadLetterSink.
+type DeadLetterFunc[T any] func(ctx context.Context, record T, err error) error
+
+// interface guard
+var _ DeadLetterSink[string] = DeadLetterFunc[string](nil)
+
+// Send calls f(ctx, record, err).
+func (f DeadLetterFunc[T]) Send(ctx context.Context, record T, err error) error {
+	return f(ctx, record, err)
+}
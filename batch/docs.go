@@ -0,0 +1,7 @@
+// Package batch runs a Result-returning function over a large set of records
+// and reports how many succeeded and why the rest failed.
+//
+// A run can checkpoint the keys of records that were processed Ok to a local
+// file so an interrupted run resumes without repeating them. Records that fail
+// can be routed to a DeadLetterSink.
+package batch
@@ -0,0 +1,138 @@
+package batch
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"text/tabwriter"
+)
+
+const (
+	// sampleSize bounds how many record keys each ErrorGroup keeps.
+	sampleSize = 10
+	// groupLimit bounds how many distinct groups a Report keeps before
+	// further failures are collapsed into OtherGroup.
+	groupLimit = 100
+)
+
+// OtherGroup is the group that collects failures once a Report holds 100
+// distinct groups, so that messages carrying record ids cannot grow the
+// report without bound.
+const OtherGroup = "(other)"
+
+// Report summarises a run.
+type Report struct {
+	// Succeeded counts records processed Ok during this run.
+	Succeeded int `json:"succeeded"`
+	// Failed counts records processed Err during this run.
+	Failed int `json:"failed"`
+	// Skipped counts records already Ok in the checkpoint.
+	Skipped int `json:"skipped"`
+	// Errors groups the failures, largest group first. Failures past the
+	// first 100 groups are counted under OtherGroup.
+	Errors []ErrorGroup `json:"errors"`
+
+	// groups indexes Errors by group while the run is in progress.
+	groups map[string]int
+}
+
+// ErrorGroup is a set of failures that share a grouping key.
+type ErrorGroup struct {
+	// Group is the key produced by the run's Grouping.
+	Group string `json:"group"`
+	// Count is the number of failures in the group.
+	Count int `json:"count"`
+	// Records holds the keys of the first failed records in the group.
+	Records []string `json:"records"`
+}
+
+// Grouping derives the key failures are grouped under.
+type Grouping func(err error) string
+
+// ByMessage groups failures by their error message.
+func ByMessage(err error) string {
+	return err.Error()
+}
+
+// ByType groups failures by the type of their root cause, found by following
+// Unwrap so that errors wrapped with fmt.Errorf group with their cause.
+func ByType(err error) string {
+	for {
+		next := errors.Unwrap(err)
+		if next == nil {
+			return fmt.Sprintf("%T", err)
+		}
+		err = next
+	}
+}
+
+// Processed returns the number of records processed during this run.
+func (r *Report) Processed() int {
+	return r.Succeeded + r.Failed
+}
+
+// WriteText writes the report as aligned plain-text tables.
+//
+// Example output:
+//
+//	STATUS     COUNT
+//	succeeded  98
+//	failed     2
+//	skipped    0
+//
+//	ERROR      COUNT  RECORDS
+//	not found  2      r-17, r-42
+func (r *Report) WriteText(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "STATUS\tCOUNT")
+	fmt.Fprintf(tw, "succeeded\t%d\n", r.Succeeded)
+	fmt.Fprintf(tw, "failed\t%d\n", r.Failed)
+	fmt.Fprintf(tw, "skipped\t%d\n", r.Skipped)
+	if len(r.Errors) > 0 {
+		fmt.Fprintln(tw)
+		fmt.Fprintln(tw, "ERROR\tCOUNT\tRECORDS")
+		for _, group := range r.Errors {
+			fmt.Fprintf(tw, "%s\t%d\t%s\n", group.Group, group.Count, strings.Join(group.Records, ", "))
+		}
+	}
+	return tw.Flush()
+}
+
+// WriteJSON writes the report as indented JSON.
+func (r *Report) WriteJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(r)
+}
+
+func (r *Report) fail(group, key string) {
+	if r.groups == nil {
+		r.groups = make(map[string]int)
+	}
+	i, ok := r.groups[group]
+	if !ok && len(r.Errors) >= groupLimit {
+		group = OtherGroup
+		i, ok = r.groups[group]
+	}
+	if !ok {
+		r.Errors = append(r.Errors, ErrorGroup{Group: group, Records: []string{}})
+		i = len(r.Errors) - 1
+		r.groups[group] = i
+	}
+	r.Errors[i].Count++
+	if len(r.Errors[i].Records) < sampleSize {
+		r.Errors[i].Records = append(r.Errors[i].Records, key)
+	}
+	r.Failed++
+}
+
+func (r *Report) sort() {
+	// sorting invalidates the index; the report is complete by now
+	r.groups = nil
+	slices.SortStableFunc(r.Errors, func(a, b ErrorGroup) int {
+		return b.Count - a.Count
+	})
+}
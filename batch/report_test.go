@@ -0,0 +1,90 @@
+package batch_test
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/shoenig/test/must"
+
+	"codeberg.org/yaadata/opt/batch"
+)
+
+func TestGrouping(t *testing.T) {
+	t.Parallel()
+	t.Run("ByMessage uses the full message", func(t *testing.T) {
+		t.Parallel()
+		// [A]rrange
+		err := fmt.Errorf("record 7: %w", os.ErrNotExist)
+		// [A]ct
+		actual := batch.ByMessage(err)
+		// [A]ssert
+		must.Eq(t, "record 7: file does not exist", actual)
+	})
+
+	t.Run("ByType uses the root cause", func(t *testing.T) {
+		t.Parallel()
+		// [A]rrange
+		err := fmt.Errorf("record 7: %w", &os.PathError{Op: "open", Path: "x", Err: errors.ErrUnsupported})
+		// [A]ct
+		actual := batch.ByType(err)
+		// [A]ssert
+		must.Eq(t, "*errors.errorString", actual)
+	})
+}
+
+func TestReport_Write(t *testing.T) {
+	t.Parallel()
+	report := &batch.Report{
+		Succeeded: 98,
+		Failed:    2,
+		Errors:    []batch.ErrorGroup{{Group: "not found", Count: 2, Records: []string{"r-17", "r-42"}}},
+	}
+	t.Run("WriteText renders tables", func(t *testing.T) {
+		t.Parallel()
+		// [A]rrange
+		var buf bytes.Buffer
+		// [A]ct
+		err := report.WriteText(&buf)
+		// [A]ssert
+		must.NoError(t, err)
+		must.Eq(t, ""+
+			"STATUS     COUNT\n"+
+			"succeeded  98\n"+
+			"failed     2\n"+
+			"skipped    0\n"+
+			"\n"+
+			"ERROR      COUNT  RECORDS\n"+
+			"not found  2      r-17, r-42\n",
+			buf.String(),
+		)
+	})
+
+	t.Run("WriteJSON renders machine readable output", func(t *testing.T) {
+		t.Parallel()
+		// [A]rrange
+		var buf bytes.Buffer
+		// [A]ct
+		err := report.WriteJSON(&buf)
+		// [A]ssert
+		must.NoError(t, err)
+		must.Eq(t, `{
+  "succeeded": 98,
+  "failed": 2,
+  "skipped": 0,
+  "errors": [
+    {
+      "group": "not found",
+      "count": 2,
+      "records": [
+        "r-17",
+        "r-42"
+      ]
+    }
+  ]
+}
+`, buf.String())
+	})
+}
@@ -0,0 +1,128 @@
+package batch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"iter"
+
+	"codeberg.org/yaadata/opt/core"
+	"codeberg.org/yaadata/opt/internal"
+)
+
+const (
+	// defaultCheckpointEvery is how many Ok records are buffered before the
+	// checkpoint file is flushed.
+	defaultCheckpointEvery = 1000
+)
+
+// Runner processes records with a Result-returning function.
+type Runner[T, V any] struct {
+	process         func(T) core.Result[V]
+	key             func(T) string
+	grouping        Grouping
+	checkpointPath  string
+	checkpointEvery int
+	deadLetter      DeadLetterSink[T]
+}
+
+// New creates a Runner. key must return a stable, unique identifier for a
+// record; it names records in reports and in the checkpoint.
+//
+// Example:
+//
+//	runner := New(importInvoice, func(inv Invoice) string { return inv.ID }).
+//	    WithCheckpoint("invoices.checkpoint").
+//	    WithDeadLetter(queue)
+//	report := runner.Run(ctx, slices.Values(invoices)).Unwrap()
+//	report.WriteText(os.Stdout)
+func New[T, V any](process func(T) core.Result[V], key func(T) string) *Runner[T, V] {
+	return &Runner[T, V]{
+		process:         process,
+		key:             key,
+		grouping:        ByMessage,
+		checkpointEvery: defaultCheckpointEvery,
+	}
+}
+
+// WithCheckpoint records the key of every Ok record in the file at path.
+// Records whose key is already in the file are skipped.
+func (r *Runner[T, V]) WithCheckpoint(path string) *Runner[T, V] {
+	r.checkpointPath = path
+	return r
+}
+
+// WithCheckpointEvery flushes the checkpoint file after every n Ok records
+// instead of the default 1000. The file is always flushed when the run ends.
+// Panics if n is less than one.
+func (r *Runner[T, V]) WithCheckpointEvery(n int) *Runner[T, V] {
+	if n < 1 {
+		panic("batch: checkpoint interval must be at least one")
+	}
+	r.checkpointEvery = n
+	return r
+}
+
+// WithDeadLetter routes every failed record to sink.
+func (r *Runner[T, V]) WithDeadLetter(sink DeadLetterSink[T]) *Runner[T, V] {
+	r.deadLetter = sink
+	return r
+}
+
+// WithGrouping sets how failures are grouped in the report. Defaults to ByMessage.
+func (r *Runner[T, V]) WithGrouping(grouping Grouping) *Runner[T, V] {
+	r.grouping = grouping
+	return r
+}
+
+// Run processes every record not already checkpointed and returns Ok(report).
+//
+// Run stops early and returns Err when ctx ends, the checkpoint cannot be
+// read or written, or the dead-letter sink rejects a record. Progress made up
+// to that point is kept in the checkpoint, so running again resumes the batch.
+func (r *Runner[T, V]) Run(ctx context.Context, records iter.Seq[T]) (res core.Result[*Report]) {
+	var cp *checkpoint
+	if r.checkpointPath != "" {
+		opened, err := openCheckpoint(r.checkpointPath, r.checkpointEvery)
+		if err != nil {
+			return internal.Err[*Report](err)
+		}
+		cp = opened
+		defer func() {
+			if err := cp.close(); err != nil {
+				res = internal.Err[*Report](errors.Join(res.Err().UnwrapOrDefault(), err))
+			}
+		}()
+	}
+
+	report := &Report{Errors: []ErrorGroup{}}
+	for record := range records {
+		if err := ctx.Err(); err != nil {
+			return internal.Err[*Report](err)
+		}
+		key := r.key(record)
+		if cp != nil && cp.contains(key) {
+			report.Skipped++
+			continue
+		}
+		out := r.process(record)
+		if out.IsOk() {
+			report.Succeeded++
+			if cp != nil {
+				if err := cp.record(key); err != nil {
+					return internal.Err[*Report](err)
+				}
+			}
+			continue
+		}
+		err := out.UnwrapErr()
+		report.fail(r.grouping(err), key)
+		if r.deadLetter != nil {
+			if sendErr := r.deadLetter.Send(ctx, record, err); sendErr != nil {
+				return internal.Err[*Report](fmt.Errorf("dead-letter record %q: %w", key, sendErr))
+			}
+		}
+	}
+	report.sort()
+	return internal.Ok(report)
+}
@@ -0,0 +1,212 @@
+package batch_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"testing"
+
+	"github.com/shoenig/test/must"
+
+	"codeberg.org/yaadata/opt/batch"
+	"codeberg.org/yaadata/opt/core"
+	"codeberg.org/yaadata/opt/internal"
+)
+
+var errOdd = errors.New("odd record")
+
+func evensOnly(processed *[]int) func(int) core.Result[string] {
+	return func(record int) core.Result[string] {
+		*processed = append(*processed, record)
+		if record%2 != 0 {
+			return internal.Err[string](errOdd)
+		}
+		return internal.Ok(strconv.Itoa(record))
+	}
+}
+
+func TestRunner_Run(t *testing.T) {
+	t.Parallel()
+	t.Run("Counts successes and failures", func(t *testing.T) {
+		t.Parallel()
+		// [A]rrange
+		var processed []int
+		runner := batch.New(evensOnly(&processed), strconv.Itoa)
+		// [A]ct
+		actual := runner.Run(context.Background(), slices.Values([]int{1, 2, 3, 4, 5}))
+		// [A]ssert
+		report := actual.Unwrap()
+		must.Eq(t, 2, report.Succeeded)
+		must.Eq(t, 3, report.Failed)
+		must.Eq(t, 5, report.Processed())
+		must.Eq(t, []batch.ErrorGroup{{Group: "odd record", Count: 3, Records: []string{"1", "3", "5"}}}, report.Errors)
+	})
+
+	t.Run("Groups failures largest first", func(t *testing.T) {
+		t.Parallel()
+		// [A]rrange
+		process := func(record int) core.Result[int] {
+			if record < 3 {
+				return internal.Err[int](errors.New("small"))
+			}
+			return internal.Err[int](errors.New("large"))
+		}
+		runner := batch.New(process, strconv.Itoa)
+		// [A]ct
+		actual := runner.Run(context.Background(), slices.Values([]int{1, 3, 4, 5}))
+		// [A]ssert
+		report := actual.Unwrap()
+		must.Eq(t, "large", report.Errors[0].Group)
+		must.Eq(t, 3, report.Errors[0].Count)
+		must.Eq(t, "small", report.Errors[1].Group)
+	})
+
+	t.Run("Distinct messages past the group limit are collapsed", func(t *testing.T) {
+		t.Parallel()
+		// [A]rrange
+		process := func(record int) core.Result[int] {
+			return internal.Err[int](fmt.Errorf("record %d: not found", record))
+		}
+		records := make([]int, 10_000)
+		for i := range records {
+			records[i] = i
+		}
+		runner := batch.New(process, strconv.Itoa)
+		// [A]ct
+		actual := runner.Run(context.Background(), slices.Values(records))
+		// [A]ssert
+		report := actual.Unwrap()
+		must.Eq(t, 10_000, report.Failed)
+		must.SliceLen(t, 101, report.Errors)
+		must.Eq(t, batch.OtherGroup, report.Errors[0].Group)
+		must.Eq(t, 9_900, report.Errors[0].Count)
+		must.Eq(t, []string{"100", "101", "102", "103", "104", "105", "106", "107", "108", "109"}, report.Errors[0].Records)
+	})
+
+	t.Run("Failures are sent to the dead-letter sink", func(t *testing.T) {
+		t.Parallel()
+		// [A]rrange
+		var processed []int
+		var dead []string
+		sink := batch.DeadLetterFunc[int](func(_ context.Context, record int, err error) error {
+			dead = append(dead, fmt.Sprintf("%d: %v", record, err))
+			return nil
+		})
+		runner := batch.New(evensOnly(&processed), strconv.Itoa).WithDeadLetter(sink)
+		// [A]ct
+		actual := runner.Run(context.Background(), slices.Values([]int{1, 2, 3}))
+		// [A]ssert
+		must.True(t, actual.IsOk())
+		must.Eq(t, []string{"1: odd record", "3: odd record"}, dead)
+	})
+
+	t.Run("Dead-letter failure stops the run", func(t *testing.T) {
+		t.Parallel()
+		// [A]rrange
+		var processed []int
+		sinkErr := errors.New("queue unavailable")
+		sink := batch.DeadLetterFunc[int](func(_ context.Context, _ int, _ error) error {
+			return sinkErr
+		})
+		runner := batch.New(evensOnly(&processed), strconv.Itoa).WithDeadLetter(sink)
+		// [A]ct
+		actual := runner.Run(context.Background(), slices.Values([]int{2, 3, 4}))
+		// [A]ssert
+		must.ErrorIs(t, actual.UnwrapErr(), sinkErr)
+		must.Eq(t, []int{2, 3}, processed)
+	})
+
+	t.Run("Cancelled context stops the run", func(t *testing.T) {
+		t.Parallel()
+		// [A]rrange
+		ctx, cancel := context.WithCancel(context.Background())
+		process := func(record int) core.Result[int] {
+			cancel()
+			return internal.Ok(record)
+		}
+		runner := batch.New(process, strconv.Itoa)
+		// [A]ct
+		actual := runner.Run(ctx, slices.Values([]int{1, 2}))
+		// [A]ssert
+		must.ErrorIs(t, actual.UnwrapErr(), context.Canceled)
+	})
+}
+
+func TestRunner_Checkpoint(t *testing.T) {
+	t.Parallel()
+	t.Run("Resumed run skips records already Ok", func(t *testing.T) {
+		t.Parallel()
+		// [A]rrange
+		path := filepath.Join(t.TempDir(), "run.checkpoint")
+		var first []int
+		must.True(t, batch.New(evensOnly(&first), strconv.Itoa).
+			WithCheckpoint(path).
+			Run(context.Background(), slices.Values([]int{1, 2, 3, 4})).
+			IsOk())
+		var second []int
+		runner := batch.New(evensOnly(&second), strconv.Itoa).WithCheckpoint(path)
+		// [A]ct
+		actual := runner.Run(context.Background(), slices.Values([]int{1, 2, 3, 4, 6}))
+		// [A]ssert
+		report := actual.Unwrap()
+		must.Eq(t, []int{1, 3, 6}, second)
+		must.Eq(t, 2, report.Skipped)
+		must.Eq(t, 1, report.Succeeded)
+		must.Eq(t, 2, report.Failed)
+	})
+
+	t.Run("Progress is kept when a run is interrupted", func(t *testing.T) {
+		t.Parallel()
+		// [A]rrange
+		path := filepath.Join(t.TempDir(), "run.checkpoint")
+		ctx, cancel := context.WithCancel(context.Background())
+		interrupted := func(record int) core.Result[int] {
+			if record == 4 {
+				cancel()
+			}
+			return internal.Ok(record)
+		}
+		must.True(t, batch.New(interrupted, strconv.Itoa).
+			WithCheckpoint(path).
+			Run(ctx, slices.Values([]int{2, 4, 6, 8})).
+			IsError())
+		var resumed []int
+		runner := batch.New(evensOnly(&resumed), strconv.Itoa).WithCheckpoint(path)
+		// [A]ct
+		actual := runner.Run(context.Background(), slices.Values([]int{2, 4, 6, 8}))
+		// [A]ssert
+		must.Eq(t, []int{6, 8}, resumed)
+		must.Eq(t, 2, actual.Unwrap().Skipped)
+	})
+
+	t.Run("Torn final line is ignored", func(t *testing.T) {
+		t.Parallel()
+		// [A]rrange
+		path := filepath.Join(t.TempDir(), "run.checkpoint")
+		must.NoError(t, os.WriteFile(path, []byte("\"2\"\n\"4"), 0o644))
+		var processed []int
+		runner := batch.New(evensOnly(&processed), strconv.Itoa).WithCheckpoint(path)
+		// [A]ct
+		actual := runner.Run(context.Background(), slices.Values([]int{2, 4}))
+		// [A]ssert
+		must.Eq(t, []int{4}, processed)
+		must.Eq(t, 1, actual.Unwrap().Skipped)
+	})
+
+	t.Run("Unreadable checkpoint is an error", func(t *testing.T) {
+		t.Parallel()
+		// [A]rrange
+		path := filepath.Join(t.TempDir(), "missing-dir", "run.checkpoint")
+		var processed []int
+		runner := batch.New(evensOnly(&processed), strconv.Itoa).WithCheckpoint(path)
+		// [A]ct
+		actual := runner.Run(context.Background(), slices.Values([]int{2}))
+		// [A]ssert
+		must.True(t, actual.IsError())
+		must.SliceEmpty(t, processed)
+	})
+}
@@ -0,0 +1,132 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"runtime"
+)
+
+const (
+	// UnwrapKindNone is an Unwrap or Expect on a None Option.
+	UnwrapKindNone UnwrapKind = iota
+	// UnwrapKindErr is an Unwrap or Expect on an Err Result.
+	UnwrapKindErr
+	// UnwrapKindOk is an UnwrapErr or ExpectErr on an Ok Result.
+	UnwrapKindOk
+)
+
+var (
+	// ErrUnwrapNone identifies a panic raised by Unwrap or Expect on a None Option.
+	ErrUnwrapNone = errors.New("unwrap on None option")
+	// ErrUnwrapErr identifies a panic raised by Unwrap or Expect on an Err Result.
+	ErrUnwrapErr = errors.New("unwrap on Err result")
+	// ErrUnwrapOk identifies a panic raised by UnwrapErr or ExpectErr on an Ok Result.
+	ErrUnwrapOk = errors.New("unwrap error on Ok result")
+)
+
+// UnwrapKind describes which state an Option or Result was in when it was
+// unwrapped the wrong way.
+type UnwrapKind int
+
+// UnwrapError is the value Unwrap, Expect, UnwrapErr and ExpectErr panic with.
+//
+// It matches the ErrUnwrap sentinel for its Kind with errors.Is and, when a
+// Result was Err, also the error the Result contained.
+//
+// Example:
+//
+//	defer func() {
+//	    var unwrapErr *UnwrapError
+//	    if err, ok := recover().(error); ok && errors.As(err, &unwrapErr) {
+//	        log.Printf("%s at %s:%d", unwrapErr, unwrapErr.Caller.File, unwrapErr.Caller.Line)
+//	    }
+//	}()
+type UnwrapError struct {
+	// Method is the method that panicked, such as "Option.Unwrap" or "Result.ExpectErr".
+	Method string
+	// Message is the message passed to Expect, or the default message of Unwrap.
+	Message string
+	// Kind is the state the Option or Result was in.
+	Kind UnwrapKind
+	// Err is the error an Err Result contained. It is nil for other kinds.
+	Err error
+	// Caller is the frame that called the unwrapping method.
+	Caller runtime.Frame
+	// Stack is the stack trace of the panicking goroutine. It is only
+	// captured while an unwrap panic hook is registered and is nil otherwise.
+	Stack []byte
+}
+
+func (k UnwrapKind) String() string {
+	switch k {
+	case UnwrapKindNone:
+		return "None"
+	case UnwrapKindErr:
+		return "Err"
+	case UnwrapKindOk:
+		return "Ok"
+	default:
+		return fmt.Sprintf("UnwrapKind(%d)", int(k))
+	}
+}
+
+func (e *UnwrapError) Error() string {
+	if e.Err != nil {
+		return fmt.Sprintf("%s: %v", e.Message, e.Err)
+	}
+	return e.Message
+}
+
+// Unwrap returns the sentinel for the error's Kind followed by the contained
+// error, if any.
+func (e *UnwrapError) Unwrap() []error {
+	var sentinel error
+	switch e.Kind {
+	case UnwrapKindNone:
+		sentinel = ErrUnwrapNone
+	case UnwrapKindErr:
+		sentinel = ErrUnwrapErr
+	case UnwrapKindOk:
+		sentinel = ErrUnwrapOk
+	}
+	if e.Err == nil {
+		return []error{sentinel}
+	}
+	return []error{sentinel, e.Err}
+}
+
+// ValidationError is an error reported for a field of a validated value.
+// Its message is prefixed with the dotted path of the field.
+//
+// Example:
+//
+//	err := &ValidationError{Path: "address.zip", Err: errors.New("must be 5 digits")}
+//	err.Error() // "address.zip: must be 5 digits"
+type ValidationError struct {
+	// Path is the dotted path of the field, such as "address.zip" or "items[2].name".
+	Path string
+	// Err is the reason the field is invalid.
+	Err error
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("%s: %v", e.Path, e.Err)
+}
+
+func (e *ValidationError) Unwrap() error {
+	return e.Err
+}
+
+// MultiError holds every error of an Invalid Validated value, in the order
+// they were reported. Its message has one line per error, like errors.Join.
+type MultiError struct {
+	Errors []error
+}
+
+func (e *MultiError) Error() string {
+	return errors.Join(e.Errors...).Error()
+}
+
+func (e *MultiError) Unwrap() []error {
+	return e.Errors
+}
@@ -0,0 +1,45 @@
+package core
+
+import "fmt"
+
+const (
+	// KindNone is an Option that does not contain a value.
+	KindNone OptionKind = iota
+	// KindSome is an Option that contains a value.
+	KindSome
+)
+
+const (
+	// KindErr is a Result that contains an error.
+	KindErr ResultKind = iota
+	// KindOk is a Result that contains a value.
+	KindOk
+)
+
+// OptionKind is the state of an Option, as returned by Option.Kind.
+type OptionKind int
+
+// ResultKind is the state of a Result, as returned by Result.Kind.
+type ResultKind int
+
+func (k OptionKind) String() string {
+	switch k {
+	case KindNone:
+		return "None"
+	case KindSome:
+		return "Some"
+	default:
+		return fmt.Sprintf("OptionKind(%d)", int(k))
+	}
+}
+
+func (k ResultKind) String() string {
+	switch k {
+	case KindErr:
+		return "Err"
+	case KindOk:
+		return "Ok"
+	default:
+		return fmt.Sprintf("ResultKind(%d)", int(k))
+	}
+}
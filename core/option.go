@@ -38,7 +38,8 @@ type Option[T any] interface {
 	Equal(Option[T]) bool
 
 	// Expect returns the contained Some value.
-	// Panics with the provided message if the value is None.
+	// Panics with the provided message if the value is None. The panic value
+	// is an *UnwrapError of kind UnwrapKindNone.
 	//
 	// Use this method when you want to extract the value and provide a custom
 	// panic message if the value is None. This is useful for cases where you
@@ -52,6 +53,15 @@ type Option[T any] interface {
 	//	val := opt.Expect("test panic") // panics with message "test panic"
 	Expect(msg string) T
 
+	// Get returns the contained value and true if the option is Some,
+	// otherwise the zero value of type T and false, in the comma-ok style.
+	//
+	// Example:
+	//	if name, ok := opt.Get(); ok {
+	//	    fmt.Println(name)
+	//	}
+	Get() (T, bool)
+
 	// IsNone returns true if the option does not contain a value (is None).
 	//
 	// Example:
@@ -110,6 +120,17 @@ type Option[T any] interface {
 	//	}) // returns false
 	IsSomeAnd(pred shared.Predicate[T]) bool
 
+	// Kind returns KindSome if the option contains a value, otherwise KindNone.
+	//
+	// Example:
+	//	switch opt.Kind() {
+	//	case KindSome:
+	//	    fmt.Println(opt.Unwrap())
+	//	case KindNone:
+	//	    fmt.Println("no value")
+	//	}
+	Kind() OptionKind
+
 	// MapOr transforms the value or returns a default value, terminating the chain.
 	// If the current chain represents Some, applies fn to the value and returns the transformed result.
 	// If the current chain represents None, returns the provided default value 'or' without calling fn.
@@ -149,8 +170,20 @@ type Option[T any] interface {
 	//  result // "OTHER"
 	MapOrElse(fn func(T) any, orElse func() any) any
 
+	// ToPointer returns a pointer to a copy of the contained value if the
+	// option is Some, otherwise nil. Changes through the pointer do not
+	// affect the option.
+	//
+	// Example:
+	//	opt := Some("SOME")
+	//	ptr := opt.ToPointer() // *ptr == "SOME"
+	//
+	//	opt := None[string]()
+	//	ptr := opt.ToPointer() // nil
+	ToPointer() *T
+
 	// Unwrap returns the contained Some value.
-	// Panics if the value is None.
+	// Panics with an *UnwrapError of kind UnwrapKindNone if the value is None.
 	//
 	// Use this method when you are certain the option contains a value.
 	// For more control over panic messages, use Expect instead.
@@ -0,0 +1,82 @@
+package core
+
+// OptionResult is the outcome of an operation that may fail and, when it
+// succeeds, may find nothing, such as a repository lookup. It is equivalent
+// to Result[Option[T]] and converts losslessly to and from it and
+// Option[Result[T]].
+//
+// An OptionResult is either:
+//   - Some: the operation succeeded and found a value of type T
+//   - None: the operation succeeded and found nothing
+//   - Err: the operation failed with an error
+//
+// Values are transformed with extension.OptionResultMap and
+// extension.OptionResultAndThen.
+type OptionResult[T any] interface {
+	// Err returns Some(error) if the operation failed, otherwise None.
+	//
+	// Example:
+	//
+	//  Failed[int](errors.New("timeout")).Err() // Some(error)
+	//  Found(3).Err() // None
+	Err() Option[error]
+
+	// IsError returns true if the operation failed.
+	//
+	// Example:
+	//
+	//  Failed[int](errors.New("timeout")).IsError() // true
+	//  Absent[int]().IsError() // false
+	IsError() bool
+
+	// IsNone returns true if the operation succeeded and found nothing.
+	//
+	// Example:
+	//
+	//  Absent[int]().IsNone() // true
+	//  Failed[int](errors.New("timeout")).IsNone() // false
+	IsNone() bool
+
+	// IsSome returns true if the operation succeeded and found a value.
+	//
+	// Example:
+	//
+	//  Found(3).IsSome() // true
+	//  Absent[int]().IsSome() // false
+	IsSome() bool
+
+	// Option converts to Option[Result[T]]: None stays None, Some becomes
+	// Some(Ok(value)) and Err becomes Some(Err(error)).
+	//
+	// Example:
+	//
+	//  Found(3).Option() // Some(Ok(3))
+	//  Absent[int]().Option() // None
+	Option() Option[Result[T]]
+
+	// OrNotFound converts to a Result, turning None into Err(err).
+	//
+	// Example:
+	//
+	//  Found(3).OrNotFound(ErrNotFound).Unwrap() // 3
+	//  Absent[int]().OrNotFound(ErrNotFound).UnwrapErr() // ErrNotFound
+	OrNotFound(err error) Result[T]
+
+	// Result converts to Result[Option[T]]: Some becomes Ok(Some(value)),
+	// None becomes Ok(None) and Err stays Err.
+	//
+	// Example:
+	//
+	//  Found(3).Result() // Ok(Some(3))
+	//  Absent[int]().Result() // Ok(None)
+	Result() Result[Option[T]]
+
+	// UnwrapOrDefault returns the found value, or the zero value of type T if
+	// nothing was found or the operation failed.
+	//
+	// Example:
+	//
+	//  Found(3).UnwrapOrDefault() // 3
+	//  Failed[int](errors.New("timeout")).UnwrapOrDefault() // 0
+	UnwrapOrDefault() T
+}
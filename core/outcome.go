@@ -0,0 +1,84 @@
+package core
+
+// Outcome is the result of an operation that returns no value, only success
+// or an error. It is the value-less counterpart of Result and replaces
+// Result[struct{}].
+//
+// An Outcome is either:
+//   - Success: the operation completed
+//   - Failure: the operation failed with an error
+//
+// extension.OutcomeFromError converts a plain error return into an Outcome,
+// and Error converts back.
+type Outcome interface {
+	// AndThen calls fn if the outcome is Success and returns its Outcome.
+	// If the outcome is Failure, fn is not called and the Failure is returned.
+	//
+	// Example:
+	//
+	//  outcome := migrate(db).AndThen(func() Outcome {
+	//      return seed(db)
+	//  })
+	AndThen(fn func() Outcome) Outcome
+
+	// Error returns the error of a Failure, or nil for Success, for returning
+	// to code that expects a plain error.
+	//
+	// Example:
+	//
+	//  func Save(u User) error {
+	//      return store.Put(u).Error()
+	//  }
+	Error() error
+
+	// InspectErr calls fn with the error if the outcome is Failure, then
+	// returns the outcome unchanged for chaining.
+	//
+	// Example:
+	//
+	//  sendEmail(msg).InspectErr(func(err error) {
+	//      log.Printf("send failed: %v", err)
+	//  })
+	InspectErr(fn func(err error)) Outcome
+
+	// IsFailure returns true if the outcome is Failure.
+	//
+	// Example:
+	//
+	//  Failure(errors.New("error")).IsFailure() // true
+	//  Success().IsFailure() // false
+	IsFailure() bool
+
+	// IsSuccess returns true if the outcome is Success.
+	//
+	// Example:
+	//
+	//  Success().IsSuccess() // true
+	//  Failure(errors.New("error")).IsSuccess() // false
+	IsSuccess() bool
+
+	// MapErr applies fn to the error if the outcome is Failure.
+	// If the outcome is Success, it is returned unchanged.
+	//
+	// Example:
+	//
+	//  outcome := Failure(errors.New("timeout"))
+	//  outcome.MapErr(func(err error) error {
+	//      return fmt.Errorf("sending email: %w", err)
+	//  }).Error() // "sending email: timeout"
+	MapErr(fn func(err error) error) Outcome
+
+	// OrElse calls fn with the error if the outcome is Failure and returns its
+	// Outcome. If the outcome is Success, it is returned unchanged.
+	//
+	// Example:
+	//
+	//  outcome := primary.Send(msg).OrElse(func(err error) Outcome {
+	//      return fallback.Send(msg)
+	//  })
+	OrElse(fn func(err error) Outcome) Outcome
+
+	// Trace returns the call stack recorded when the Failure was created,
+	// like Result.Trace.
+	Trace() Trace
+}
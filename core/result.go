@@ -13,7 +13,8 @@ type Result[T any] interface {
 	resultToOption[T]
 
 	// Expect returns the contained Ok value.
-	// Panics with the provided message if the result is Err.
+	// Panics with the provided message if the result is Err. The panic value
+	// is an *UnwrapError of kind UnwrapKindErr wrapping the contained error.
 	//
 	// Example:
 	//
@@ -21,11 +22,12 @@ type Result[T any] interface {
 	//  value := result.Expect("ERROR_MESSAGE") // 13
 	//
 	//  result := Err[string](errors.New("err"))
-	//  value := result.Expect("TEST") // panics with "TEST"
+	//  value := result.Expect("TEST") // panics with "TEST: err"
 	Expect(msg string) T
 
 	// ExpectErr returns the contained Err value.
-	// Panics with the provided message if the result is Ok.
+	// Panics with the provided message if the result is Ok. The panic value
+	// is an *UnwrapError of kind UnwrapKindOk.
 	//
 	// Example:
 	//
@@ -37,6 +39,16 @@ type Result[T any] interface {
 	//  err := result.ExpectErr("TEST") // panics with "TEST"
 	ExpectErr(msg string) error
 
+	// Get returns the contained Ok value and a nil error, or the zero value of
+	// type T and the contained error, in the (value, error) style of Go.
+	//
+	// Example:
+	//
+	//  func LoadUser(id int) (User, error) {
+	//      return fetchUser(id).Get()
+	//  }
+	Get() (T, error)
+
 	// IsOk returns true if the result is Ok.
 	//
 	// Example:
@@ -83,8 +95,33 @@ type Result[T any] interface {
 	//  result.IsErrorAnd(func(e error) bool { return true }) // false
 	IsErrorAnd(pred shared.Predicate[error]) bool
 
+	// IsErrorIs returns true if the result is Err and errors.Is reports that
+	// the error matches target.
+	//
+	// Example:
+	//
+	//  result := Err[string](fmt.Errorf("loading: %w", fs.ErrNotExist))
+	//  result.IsErrorIs(fs.ErrNotExist) // true
+	//  result.IsErrorIs(fs.ErrExist) // false
+	//
+	//  result := Ok("value")
+	//  result.IsErrorIs(fs.ErrNotExist) // false
+	IsErrorIs(target error) bool
+
+	// Kind returns KindOk if the result is Ok, otherwise KindErr.
+	//
+	// Example:
+	//
+	//  switch result.Kind() {
+	//  case KindOk:
+	//      fmt.Println(result.Unwrap())
+	//  case KindErr:
+	//      fmt.Println(result.UnwrapErr())
+	//  }
+	Kind() ResultKind
+
 	// Unwrap returns the contained Ok value.
-	// Panics if the result is Err.
+	// Panics with an *UnwrapError of kind UnwrapKindErr wrapping the contained error if the result is Err.
 	//
 	// Example:
 	//
@@ -96,7 +133,7 @@ type Result[T any] interface {
 	Unwrap() T
 
 	// UnwrapErr returns the contained Err value.
-	// Panics if the result is Ok.
+	// Panics with an *UnwrapError of kind UnwrapKindOk if the result is Ok.
 	//
 	// Example:
 	//
@@ -129,6 +166,19 @@ type Result[T any] interface {
 	//  value := result.UnwrapOr("default") // "default"
 	UnwrapOr(value T) T
 
+	// Trace returns the call stack recorded when the Err was created.
+	// It is nil for Ok results and for Errs created while trace capture was off.
+	// Capture is off unless the opttrace build tag is set or it is turned on
+	// with extension.SetTraceCapture. Formatting a Result with %+v prints the trace.
+	//
+	// Example:
+	//
+	//  extension.SetTraceCapture(true)
+	//  result := Err[string](errors.New("error"))
+	//  result.Trace().Frames()[0].Function // the function that called Err
+	//  fmt.Printf("%+v", result)          // Err(error) followed by the trace
+	Trace() Trace
+
 	// UnwrapOrElse returns the contained Ok value or computes it from the provided function.
 	//
 	// Example:
@@ -142,6 +192,30 @@ type Result[T any] interface {
 }
 
 type resultChain[T any] interface {
+	// Context wraps the error with msg if the result is Err, producing the
+	// message "msg: original". The original error remains reachable through
+	// errors.Is and errors.As. If the result is Ok, it is returned unchanged.
+	//
+	// Example:
+	//
+	//  result := Err[string](ErrNotFound)
+	//  wrapped := result.Context("loading user")
+	//  wrapped.UnwrapErr().Error() // "loading user: not found"
+	//  errors.Is(wrapped.UnwrapErr(), ErrNotFound) // true
+	//
+	//  result := Ok("value")
+	//  result.Context("loading user").Unwrap() // "value"
+	Context(msg string) Result[T]
+
+	// Contextf is like Context with a message formatted by fmt.Sprintf.
+	// The message is only formatted if the result is Err.
+	//
+	// Example:
+	//
+	//  result := Err[string](ErrNotFound)
+	//  wrapped := result.Contextf("loading user %d", 42)
+	//  wrapped.UnwrapErr().Error() // "loading user 42: not found"
+	Contextf(format string, args ...any) Result[T]
 
 	// Inspect calls the provided function with the Ok value if the result is Ok,
 	// then returns the result unchanged for chaining. If the result is Err, the function is not called.
@@ -272,6 +346,37 @@ type resultChain[T any] interface {
 	//      return Ok("fallback")
 	//  }).Unwrap() // "fallback"
 	OrElse(fn func(err error) Result[T]) Result[T]
+
+	// RecoverIs calls fn with the error to produce an alternative Result if the
+	// result is Err and errors.Is reports that the error matches target.
+	// Otherwise, the result is returned unchanged.
+	//
+	// Example:
+	//
+	//  result := Err[string](fs.ErrNotExist)
+	//  result.RecoverIs(fs.ErrNotExist, func(e error) Result[string] {
+	//      return Ok("default")
+	//  }).Unwrap() // "default"
+	//
+	//  result := Err[string](fs.ErrPermission)
+	//  result.RecoverIs(fs.ErrNotExist, func(e error) Result[string] {
+	//      return Ok("default")
+	//  }).UnwrapErr() // fs.ErrPermission
+	RecoverIs(target error, fn func(err error) Result[T]) Result[T]
+
+	// WithField attaches a key/value pair to the error if the result is Err.
+	// The error message is unchanged and the original error remains reachable
+	// through errors.Is and errors.As. Attached fields are read back with
+	// extension.Fields. If the result is Ok, it is returned unchanged.
+	//
+	// Example:
+	//
+	//  result := Err[string](ErrNotFound).
+	//      WithField("user_id", 42).
+	//      Context("loading user")
+	//  extension.Fields(result.UnwrapErr()) // [{user_id 42}]
+	//  result.UnwrapErr().Error()           // "loading user: not found"
+	WithField(key string, value any) Result[T]
 }
 
 type resultToOption[T any] interface {
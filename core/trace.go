@@ -0,0 +1,37 @@
+package core
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+)
+
+// Trace is the call stack, as program counters, recorded when an Err Result
+// was created while trace capture was enabled.
+type Trace []uintptr
+
+// Frames resolves the program counters into stack frames, innermost first.
+func (t Trace) Frames() []runtime.Frame {
+	if len(t) == 0 {
+		return nil
+	}
+	frames := runtime.CallersFrames(t)
+	var out []runtime.Frame
+	for {
+		frame, more := frames.Next()
+		out = append(out, frame)
+		if !more {
+			return out
+		}
+	}
+}
+
+// String formats the trace the way the Go runtime prints a goroutine stack:
+// one function per line followed by its indented file and line.
+func (t Trace) String() string {
+	var b strings.Builder
+	for _, frame := range t.Frames() {
+		fmt.Fprintf(&b, "%s\n\t%s:%d\n", frame.Function, frame.File, frame.Line)
+	}
+	return b.String()
+}
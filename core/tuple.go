@@ -0,0 +1,34 @@
+package core
+
+// Pair holds two values of possibly different types.
+//
+// Example:
+//
+//	p := Pair[string, int]{First: "port", Second: 8080}
+//	key, value := p.Unpack()
+type Pair[A, B any] struct {
+	First  A
+	Second B
+}
+
+// Triple holds three values of possibly different types.
+//
+// Example:
+//
+//	t := Triple[string, int, bool]{First: "port", Second: 8080, Third: true}
+//	key, value, enabled := t.Unpack()
+type Triple[A, B, C any] struct {
+	First  A
+	Second B
+	Third  C
+}
+
+// Unpack returns the values of the pair.
+func (p Pair[A, B]) Unpack() (A, B) {
+	return p.First, p.Second
+}
+
+// Unpack returns the values of the triple.
+func (t Triple[A, B, C]) Unpack() (A, B, C) {
+	return t.First, t.Second, t.Third
+}
@@ -0,0 +1,71 @@
+package core
+
+// Validated is the outcome of a validation that collects every error instead
+// of stopping at the first one, as Result does.
+//
+// A Validated is either:
+//   - Valid: contains a value of type T
+//   - Invalid: contains one or more errors
+//
+// Validated values are combined with extension.ValidatedMap2 to
+// extension.ValidatedMap8 and extension.ValidatedCombine, which keep the
+// errors of every Invalid input.
+type Validated[T any] interface {
+	// At prefixes the field path of every error with segment, so errors from
+	// nested values report where they came from. A segment starting with "["
+	// is appended without a separating dot. If the value is Valid, it is
+	// returned unchanged.
+	//
+	// Example:
+	//
+	//  zip := Invalid[string](errors.New("must be 5 digits")).At("zip")
+	//  address := zip.At("address")
+	//  address.Result().UnwrapErr().Error() // "address.zip: must be 5 digits"
+	//
+	//  item := Invalid[int](errors.New("must be positive")).At("[2]").At("items")
+	//  item.Result().UnwrapErr().Error() // "items[2]: must be positive"
+	At(segment string) Validated[T]
+
+	// Errors returns the errors of an Invalid value, or nil if it is Valid.
+	//
+	// Example:
+	//
+	//  v := Invalid[int](errA, errB)
+	//  v.Errors() // [errA errB]
+	Errors() []error
+
+	// IsInvalid returns true if the value is Invalid.
+	//
+	// Example:
+	//
+	//  Invalid[int](errors.New("error")).IsInvalid() // true
+	//  Valid(3).IsInvalid() // false
+	IsInvalid() bool
+
+	// IsValid returns true if the value is Valid.
+	//
+	// Example:
+	//
+	//  Valid(3).IsValid() // true
+	//  Invalid[int](errors.New("error")).IsValid() // false
+	IsValid() bool
+
+	// Result converts the value to a Result. A Valid value becomes Ok and an
+	// Invalid value becomes Err(*MultiError) holding every error.
+	//
+	// Example:
+	//
+	//  Valid(3).Result().Unwrap() // 3
+	//
+	//  v := Invalid[int](errors.New("a"), errors.New("b"))
+	//  v.Result().UnwrapErr().Error() // "a\nb"
+	Result() Result[T]
+
+	// UnwrapOr returns the Valid value or the provided default value.
+	//
+	// Example:
+	//
+	//  Valid(3).UnwrapOr(0) // 3
+	//  Invalid[int](errors.New("error")).UnwrapOr(0) // 0
+	UnwrapOr(value T) T
+}
@@ -0,0 +1,54 @@
+package core
+
+// Warned pairs a Result with non-fatal warnings, such as use of a deprecated
+// field or a truncated record. Warnings are kept through
+// extension.WarnedMap and extension.WarnedAndThen and are dropped or turned
+// into errors when converting back to a plain Result.
+//
+// A Warned formats as its Result followed by the warnings, and implements
+// slog.LogValuer so loggers record the warnings alongside the value or error.
+type Warned[T any] interface {
+	// Escalate converts to a Result that is Err if there are any warnings.
+	// An Ok value with warnings becomes Err of the joined warnings, and an Err
+	// value has the warnings joined after its error. Without warnings the
+	// Result is returned unchanged.
+	//
+	// Example:
+	//
+	//  w := WithWarnings(Ok(3), ErrDeprecatedField)
+	//  w.Escalate().IsError() // true
+	//  errors.Is(w.Escalate().UnwrapErr(), ErrDeprecatedField) // true
+	Escalate() Result[T]
+
+	// HasWarnings returns true if there is at least one warning.
+	//
+	// Example:
+	//
+	//  WithWarnings(Ok(3)).HasWarnings() // false
+	//  WithWarnings(Ok(3), ErrTruncated).HasWarnings() // true
+	HasWarnings() bool
+
+	// Result drops the warnings and returns the Result.
+	//
+	// Example:
+	//
+	//  w := WithWarnings(Ok(3), ErrTruncated)
+	//  w.Result().Unwrap() // 3
+	Result() Result[T]
+
+	// Warn returns a copy with warning appended. A nil warning is ignored.
+	//
+	// Example:
+	//
+	//  w := WithWarnings(Ok(record)).Warn(ErrTruncated)
+	//  w.Warnings() // [ErrTruncated]
+	Warn(warning error) Warned[T]
+
+	// Warnings returns the warnings in the order they were added.
+	//
+	// Example:
+	//
+	//  w := WithWarnings(Ok(3), ErrDeprecatedField, ErrTruncated)
+	//  w.Warnings() // [ErrDeprecatedField ErrTruncated]
+	Warnings() []error
+}
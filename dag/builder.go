@@ -0,0 +1,147 @@
+package dag
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"codeberg.org/yaadata/opt/core"
+	"codeberg.org/yaadata/opt/internal"
+)
+
+// Task computes a node's value from the values of its dependencies.
+type Task func(ctx context.Context, inputs Inputs) core.Result[any]
+
+// Inputs holds the Ok values of a task's dependencies, keyed by task name.
+type Inputs map[string]any
+
+// NodeOption configures a task added to a Builder.
+type NodeOption func(*node)
+
+// Builder declares tasks and their dependencies.
+type Builder struct {
+	nodes []*node
+}
+
+type node struct {
+	name    string
+	task    Task
+	deps    []string
+	retry   RetryPolicy
+	timeout time.Duration
+}
+
+// DependsOn declares the tasks that must be Ok before this task runs.
+func DependsOn(names ...string) NodeOption {
+	return func(n *node) {
+		n.deps = append(n.deps, names...)
+	}
+}
+
+// WithRetry retries the task according to policy while it returns Err.
+func WithRetry(policy RetryPolicy) NodeOption {
+	return func(n *node) {
+		n.retry = policy
+	}
+}
+
+// WithTimeout bounds each attempt of the task. An attempt that exceeds the
+// timeout sees its context cancelled.
+func WithTimeout(timeout time.Duration) NodeOption {
+	return func(n *node) {
+		n.timeout = timeout
+	}
+}
+
+// NewBuilder creates an empty Builder.
+//
+// Example:
+//
+//	graph := NewBuilder().
+//	    Add("user", fetchUser).
+//	    Add("org", fetchOrg, DependsOn("user"), WithTimeout(time.Second)).
+//	    Add("report", render, DependsOn("user", "org")).
+//	    Build()
+func NewBuilder() *Builder {
+	return &Builder{}
+}
+
+// Add declares a task. Dependencies may refer to tasks added later.
+func (b *Builder) Add(name string, task Task, opts ...NodeOption) *Builder {
+	n := &node{name: name, task: task}
+	for _, opt := range opts {
+		opt(n)
+	}
+	b.nodes = append(b.nodes, n)
+	return b
+}
+
+// Build validates the declared tasks and returns a runnable Graph.
+// Returns Err(*ErrDuplicateNode), Err(*ErrUnknownNode) or Err(*ErrCycle)
+// when the declaration is invalid.
+func (b *Builder) Build() core.Result[*Graph] {
+	byName := make(map[string]*node, len(b.nodes))
+	for _, n := range b.nodes {
+		if _, exists := byName[n.name]; exists {
+			return internal.Err[*Graph](&ErrDuplicateNode{Node: n.name})
+		}
+		byName[n.name] = n
+	}
+	for _, n := range b.nodes {
+		for _, dep := range n.deps {
+			if _, exists := byName[dep]; !exists {
+				return internal.Err[*Graph](&ErrUnknownNode{Node: n.name, Dependency: dep})
+			}
+		}
+	}
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	state := make(map[string]int, len(b.nodes))
+	var stack []string
+	var visit func(n *node) []string
+	visit = func(n *node) []string {
+		switch state[n.name] {
+		case visited:
+			return nil
+		case visiting:
+			start := slices.Index(stack, n.name)
+			return append(slices.Clone(stack[start:]), n.name)
+		}
+		state[n.name] = visiting
+		stack = append(stack, n.name)
+		for _, dep := range n.deps {
+			if cycle := visit(byName[dep]); cycle != nil {
+				return cycle
+			}
+		}
+		stack = stack[:len(stack)-1]
+		state[n.name] = visited
+		return nil
+	}
+	for _, n := range b.nodes {
+		if cycle := visit(n); cycle != nil {
+			return internal.Err[*Graph](&ErrCycle{Path: cycle})
+		}
+	}
+	return internal.Ok(&Graph{nodes: slices.Clone(b.nodes)})
+}
+
+// Get returns the value of the named dependency.
+// Returns None if the task does not depend on it or the value has another type.
+//
+// Example:
+//
+//	func render(ctx context.Context, inputs Inputs) core.Result[any] {
+//	    user := Get[User](inputs, "user").Unwrap()
+//	    ...
+//	}
+func Get[T any](inputs Inputs, name string) core.Option[T] {
+	value, ok := inputs[name].(T)
+	if !ok {
+		return internal.None[T]()
+	}
+	return internal.Some(value)
+}
@@ -0,0 +1,86 @@
+package dag_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/shoenig/test/must"
+
+	"codeberg.org/yaadata/opt/core"
+	"codeberg.org/yaadata/opt/dag"
+	"codeberg.org/yaadata/opt/internal"
+)
+
+func constant(value any) dag.Task {
+	return func(_ context.Context, _ dag.Inputs) core.Result[any] {
+		return internal.Ok(value)
+	}
+}
+
+func TestBuilder_Build(t *testing.T) {
+	t.Parallel()
+	t.Run("Valid graph builds", func(t *testing.T) {
+		t.Parallel()
+		// [A]rrange
+		builder := dag.NewBuilder().
+			Add("b", constant(2), dag.DependsOn("a")).
+			Add("a", constant(1))
+		// [A]ct
+		actual := builder.Build()
+		// [A]ssert
+		must.True(t, actual.IsOk())
+	})
+
+	t.Run("Cycle is reported with its path", func(t *testing.T) {
+		t.Parallel()
+		// [A]rrange
+		builder := dag.NewBuilder().
+			Add("a", constant(1)).
+			Add("b", constant(2), dag.DependsOn("a", "d")).
+			Add("c", constant(3), dag.DependsOn("b")).
+			Add("d", constant(4), dag.DependsOn("c"))
+		// [A]ct
+		actual := builder.Build()
+		// [A]ssert
+		var cycle *dag.ErrCycle
+		must.True(t, errors.As(actual.UnwrapErr(), &cycle))
+		must.Eq(t, []string{"b", "d", "c", "b"}, cycle.Path)
+		must.EqError(t, cycle, "dependency cycle: b -> d -> c -> b")
+	})
+
+	t.Run("Self dependency is a cycle", func(t *testing.T) {
+		t.Parallel()
+		// [A]rrange
+		builder := dag.NewBuilder().Add("a", constant(1), dag.DependsOn("a"))
+		// [A]ct
+		actual := builder.Build()
+		// [A]ssert
+		var cycle *dag.ErrCycle
+		must.True(t, errors.As(actual.UnwrapErr(), &cycle))
+		must.Eq(t, []string{"a", "a"}, cycle.Path)
+	})
+
+	t.Run("Unknown dependency is reported", func(t *testing.T) {
+		t.Parallel()
+		// [A]rrange
+		builder := dag.NewBuilder().Add("a", constant(1), dag.DependsOn("missing"))
+		// [A]ct
+		actual := builder.Build()
+		// [A]ssert
+		var unknown *dag.ErrUnknownNode
+		must.True(t, errors.As(actual.UnwrapErr(), &unknown))
+		must.Eq(t, "missing", unknown.Dependency)
+	})
+
+	t.Run("Duplicate task is reported", func(t *testing.T) {
+		t.Parallel()
+		// [A]rrange
+		builder := dag.NewBuilder().Add("a", constant(1)).Add("a", constant(2))
+		// [A]ct
+		actual := builder.Build()
+		// [A]ssert
+		var duplicate *dag.ErrDuplicateNode
+		must.True(t, errors.As(actual.UnwrapErr(), &duplicate))
+	})
+}
@@ -0,0 +1,6 @@
+// Package dag executes a graph of Result-returning tasks with maximal parallelism.
+//
+// Every task runs as soon as all of its dependencies are Ok and receives their
+// values as inputs. When a task fails, everything downstream of it is skipped
+// and reported as Err(*ErrUpstreamFailed).
+package dag
@@ -0,0 +1,46 @@
+package dag
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ErrUpstreamFailed is reported for a task that was skipped because a task it
+// depends on, directly or transitively, did not return Ok.
+type ErrUpstreamFailed struct {
+	// Node is the name of the task whose failure caused the skip.
+	Node string
+}
+
+// ErrCycle is returned by Build when the dependencies form a cycle.
+type ErrCycle struct {
+	// Path lists the tasks on the cycle, starting and ending with the same task.
+	Path []string
+}
+
+// ErrUnknownNode is returned by Build when a task depends on a task that was never added.
+type ErrUnknownNode struct {
+	Node       string
+	Dependency string
+}
+
+// ErrDuplicateNode is returned by Build when two tasks share a name.
+type ErrDuplicateNode struct {
+	Node string
+}
+
+func (e *ErrUpstreamFailed) Error() string {
+	return fmt.Sprintf("upstream task %q failed", e.Node)
+}
+
+func (e *ErrCycle) Error() string {
+	return fmt.Sprintf("dependency cycle: %s", strings.Join(e.Path, " -> "))
+}
+
+func (e *ErrUnknownNode) Error() string {
+	return fmt.Sprintf("task %q depends on unknown task %q", e.Node, e.Dependency)
+}
+
+func (e *ErrDuplicateNode) Error() string {
+	return fmt.Sprintf("task %q added more than once", e.Node)
+}
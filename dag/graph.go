@@ -0,0 +1,111 @@
+package dag
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"codeberg.org/yaadata/opt/core"
+	"codeberg.org/yaadata/opt/internal"
+)
+
+// Graph is a validated set of tasks ready to run.
+type Graph struct {
+	nodes []*node
+}
+
+// Report maps every task name to the Result it finished with.
+type Report map[string]core.Result[any]
+
+// Run executes every task, each one as soon as all of its dependencies are Ok.
+// A task whose dependency did not return Ok is not run and is reported as
+// Err(*ErrUpstreamFailed) naming the task that failed first in its ancestry.
+// Tasks that had not started when ctx ends are reported as Err(ctx.Err()).
+//
+// A Graph can be run any number of times.
+func (g *Graph) Run(ctx context.Context) Report {
+	done := make(map[string]chan struct{}, len(g.nodes))
+	for _, n := range g.nodes {
+		done[n.name] = make(chan struct{})
+	}
+	var (
+		mu     sync.Mutex
+		report = make(Report, len(g.nodes))
+		wg     sync.WaitGroup
+	)
+	for _, n := range g.nodes {
+		wg.Go(func() {
+			defer close(done[n.name])
+			for _, dep := range n.deps {
+				<-done[dep]
+			}
+			mu.Lock()
+			inputs := make(Inputs, len(n.deps))
+			var upstream *ErrUpstreamFailed
+			for _, dep := range n.deps {
+				res := report[dep]
+				if res.IsOk() {
+					inputs[dep] = res.Unwrap()
+					continue
+				}
+				upstream = upstreamOf(dep, res.UnwrapErr())
+				break
+			}
+			mu.Unlock()
+
+			var res core.Result[any]
+			if upstream != nil {
+				res = internal.Err[any](upstream)
+			} else {
+				res = n.run(ctx, inputs)
+			}
+			mu.Lock()
+			report[n.name] = res
+			mu.Unlock()
+		})
+	}
+	wg.Wait()
+	return report
+}
+
+// upstreamOf blames the dependency that failed, or keeps the original
+// culprit when the dependency was itself skipped.
+func upstreamOf(dep string, err error) *ErrUpstreamFailed {
+	// a direct assertion so task errors that merely wrap a skip are still blamed on dep
+	if skipped, ok := err.(*ErrUpstreamFailed); ok {
+		return skipped
+	}
+	return &ErrUpstreamFailed{Node: dep}
+}
+
+func (n *node) run(ctx context.Context, inputs Inputs) core.Result[any] {
+	for attempt := 1; ; attempt++ {
+		if err := ctx.Err(); err != nil {
+			return internal.Err[any](err)
+		}
+		res := n.attempt(ctx, inputs)
+		if res.IsOk() || n.retry == nil {
+			return res
+		}
+		delay := n.retry.Next(attempt, res.UnwrapErr())
+		if delay.IsNone() {
+			return res
+		}
+		timer := time.NewTimer(delay.Unwrap())
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return res
+		}
+	}
+}
+
+func (n *node) attempt(ctx context.Context, inputs Inputs) core.Result[any] {
+	if n.timeout <= 0 {
+		return n.task(ctx, inputs)
+	}
+	ctx, cancel := context.WithTimeout(ctx, n.timeout)
+	defer cancel()
+	return n.task(ctx, inputs)
+}
@@ -0,0 +1,175 @@
+package dag_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"testing/synctest"
+	"time"
+
+	"github.com/shoenig/test/must"
+
+	"codeberg.org/yaadata/opt/core"
+	"codeberg.org/yaadata/opt/dag"
+	"codeberg.org/yaadata/opt/internal"
+)
+
+func sleepy(d time.Duration, value any) dag.Task {
+	return func(ctx context.Context, _ dag.Inputs) core.Result[any] {
+		select {
+		case <-time.After(d):
+			return internal.Ok(value)
+		case <-ctx.Done():
+			return internal.Err[any](ctx.Err())
+		}
+	}
+}
+
+func TestGraph_Run(t *testing.T) {
+	t.Parallel()
+	t.Run("Dependencies feed their values as inputs", func(t *testing.T) {
+		t.Parallel()
+		// [A]rrange
+		sum := func(_ context.Context, inputs dag.Inputs) core.Result[any] {
+			return internal.Ok[any](dag.Get[int](inputs, "a").Unwrap() + dag.Get[int](inputs, "b").Unwrap())
+		}
+		graph := dag.NewBuilder().
+			Add("a", constant(1)).
+			Add("b", constant(2)).
+			Add("sum", sum, dag.DependsOn("a", "b")).
+			Build().
+			Unwrap()
+		// [A]ct
+		actual := graph.Run(context.Background())
+		// [A]ssert
+		must.MapLen(t, 3, actual)
+		must.Eq(t, 3, actual["sum"].Unwrap())
+	})
+
+	t.Run("Independent tasks run in parallel", func(t *testing.T) {
+		t.Parallel()
+		synctest.Test(t, func(t *testing.T) {
+			// [A]rrange
+			graph := dag.NewBuilder().
+				Add("a", sleepy(time.Second, 1)).
+				Add("b", sleepy(time.Second, 2)).
+				Add("c", sleepy(time.Second, 3), dag.DependsOn("a", "b")).
+				Build().
+				Unwrap()
+			start := time.Now()
+			// [A]ct
+			actual := graph.Run(context.Background())
+			// [A]ssert
+			must.Eq(t, 2*time.Second, time.Since(start))
+			must.True(t, actual["c"].IsOk())
+		})
+	})
+
+	t.Run("Failure skips everything downstream", func(t *testing.T) {
+		t.Parallel()
+		// [A]rrange
+		cause := errors.New("boom")
+		var ran atomic.Int32
+		counted := func(_ context.Context, _ dag.Inputs) core.Result[any] {
+			ran.Add(1)
+			return internal.Ok[any](nil)
+		}
+		graph := dag.NewBuilder().
+			Add("a", func(_ context.Context, _ dag.Inputs) core.Result[any] { return internal.Err[any](cause) }).
+			Add("b", counted, dag.DependsOn("a")).
+			Add("c", counted, dag.DependsOn("b")).
+			Add("d", counted).
+			Build().
+			Unwrap()
+		// [A]ct
+		actual := graph.Run(context.Background())
+		// [A]ssert
+		must.Eq(t, int32(1), ran.Load())
+		must.Eq(t, cause, actual["a"].UnwrapErr())
+		for _, name := range []string{"b", "c"} {
+			var upstream *dag.ErrUpstreamFailed
+			must.True(t, errors.As(actual[name].UnwrapErr(), &upstream))
+			must.Eq(t, "a", upstream.Node)
+		}
+		must.True(t, actual["d"].IsOk())
+	})
+
+	t.Run("Retry policy reruns a failing task", func(t *testing.T) {
+		t.Parallel()
+		synctest.Test(t, func(t *testing.T) {
+			// [A]rrange
+			var attempts atomic.Int32
+			flaky := func(_ context.Context, _ dag.Inputs) core.Result[any] {
+				if attempts.Add(1) < 3 {
+					return internal.Err[any](errors.New("flaky"))
+				}
+				return internal.Ok[any]("done")
+			}
+			graph := dag.NewBuilder().
+				Add("a", flaky, dag.WithRetry(dag.ConstantRetry(3, time.Second))).
+				Build().
+				Unwrap()
+			start := time.Now()
+			// [A]ct
+			actual := graph.Run(context.Background())
+			// [A]ssert
+			must.Eq(t, "done", actual["a"].Unwrap())
+			must.Eq(t, int32(3), attempts.Load())
+			must.Eq(t, 2*time.Second, time.Since(start))
+		})
+	})
+
+	t.Run("Retry policy gives up", func(t *testing.T) {
+		t.Parallel()
+		// [A]rrange
+		var attempts atomic.Int32
+		failing := func(_ context.Context, _ dag.Inputs) core.Result[any] {
+			attempts.Add(1)
+			return internal.Err[any](errors.New("down"))
+		}
+		graph := dag.NewBuilder().
+			Add("a", failing, dag.WithRetry(dag.ConstantRetry(2, 0))).
+			Build().
+			Unwrap()
+		// [A]ct
+		actual := graph.Run(context.Background())
+		// [A]ssert
+		must.True(t, actual["a"].IsError())
+		must.Eq(t, int32(2), attempts.Load())
+	})
+
+	t.Run("Timeout cancels a slow task", func(t *testing.T) {
+		t.Parallel()
+		synctest.Test(t, func(t *testing.T) {
+			// [A]rrange
+			graph := dag.NewBuilder().
+				Add("a", sleepy(time.Minute, 1), dag.WithTimeout(time.Second)).
+				Build().
+				Unwrap()
+			// [A]ct
+			actual := graph.Run(context.Background())
+			// [A]ssert
+			must.ErrorIs(t, actual["a"].UnwrapErr(), context.DeadlineExceeded)
+		})
+	})
+
+	t.Run("Cancelled context stops tasks that have not started", func(t *testing.T) {
+		t.Parallel()
+		// [A]rrange
+		ctx, cancel := context.WithCancel(context.Background())
+		graph := dag.NewBuilder().
+			Add("a", func(_ context.Context, _ dag.Inputs) core.Result[any] {
+				cancel()
+				return internal.Ok[any](1)
+			}).
+			Add("b", constant(2), dag.DependsOn("a")).
+			Build().
+			Unwrap()
+		// [A]ct
+		actual := graph.Run(ctx)
+		// [A]ssert
+		must.True(t, actual["a"].IsOk())
+		must.ErrorIs(t, actual["b"].UnwrapErr(), context.Canceled)
+	})
+}
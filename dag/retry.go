@@ -0,0 +1,44 @@
+package dag
+
+import (
+	"time"
+
+	"codeberg.org/yaadata/opt/core"
+	"codeberg.org/yaadata/opt/internal"
+)
+
+// RetryPolicy decides whether a failed task attempt is retried.
+type RetryPolicy interface {
+	// Next is called after the given attempt (starting at 1) failed with err.
+	// Returns Some(delay) to retry after delay, or None to give up.
+	Next(attempt int, err error) core.Option[time.Duration]
+}
+
+// RetryFunc adapts a function to a RetryPolicy.
+type RetryFunc func(attempt int, err error) core.Option[time.Duration]
+
+type constantRetry struct {
+	attempts int
+	delay    time.Duration
+}
+
+// Next calls f(attempt, err).
+func (f RetryFunc) Next(attempt int, err error) core.Option[time.Duration] {
+	return f(attempt, err)
+}
+
+// ConstantRetry retries a task until it has run attempts times, waiting delay between attempts.
+//
+// Example:
+//
+//	builder.Add("fetch", fetch, WithRetry(ConstantRetry(3, time.Second)))
+func ConstantRetry(attempts int, delay time.Duration) RetryPolicy {
+	return constantRetry{attempts: attempts, delay: delay}
+}
+
+func (c constantRetry) Next(attempt int, _ error) core.Option[time.Duration] {
+	if attempt >= c.attempts {
+		return internal.None[time.Duration]()
+	}
+	return internal.Some(c.delay)
+}
@@ -11,6 +11,24 @@ type Option[T any] = core.Option[T]
 // Result is a re-export of [core.Result]
 type Result[T any] = core.Result[T]
 
+// Pair is a re-export of [core.Pair]
+type Pair[A, B any] = core.Pair[A, B]
+
+// Triple is a re-export of [core.Triple]
+type Triple[A, B, C any] = core.Triple[A, B, C]
+
+// Outcome is a re-export of [core.Outcome]
+type Outcome = core.Outcome
+
+// OptionResult is a re-export of [core.OptionResult]
+type OptionResult[T any] = core.OptionResult[T]
+
+// Validated is a re-export of [core.Validated]
+type Validated[T any] = core.Validated[T]
+
+// Warned is a re-export of [core.Warned]
+type Warned[T any] = core.Warned[T]
+
 // None creates an Option that contains no value.
 //
 // Use None when you want to represent the absence of a value.
@@ -81,3 +99,113 @@ func Err[T any](err error) Result[T] {
 func Ok[T any](value T) Result[T] {
 	return internal.Ok(value)
 }
+
+// Valid creates a Validated containing a value.
+//
+// Example:
+//
+//	v := Valid("12345")
+//	v.IsValid() // true
+//	v.Result().Unwrap() // "12345"
+func Valid[T any](value T) Validated[T] {
+	return internal.Valid(value)
+}
+
+// Invalid creates a Validated containing one or more errors.
+// It panics if no error is given.
+//
+// Example:
+//
+//	v := Invalid[string](errors.New("must be 5 digits")).At("zip")
+//	v.IsInvalid() // true
+//	v.Result().UnwrapErr().Error() // "zip: must be 5 digits"
+func Invalid[T any](errs ...error) Validated[T] {
+	return internal.Invalid[T](errs...)
+}
+
+// WithWarnings pairs a Result with non-fatal warnings. Nil warnings are dropped.
+//
+// Example:
+//
+//	func parseRecord(line string) Warned[Record] {
+//	    record, truncated := decode(line)
+//	    w := WithWarnings(Ok(record))
+//	    if truncated {
+//	        w = w.Warn(ErrTruncated)
+//	    }
+//	    return w
+//	}
+//
+//	w := parseRecord(line)
+//	w.Result().Unwrap() // the record, warnings dropped
+//	w.Escalate()        // Err(ErrTruncated) if the record was truncated
+func WithWarnings[T any](result Result[T], warnings ...error) Warned[T] {
+	return internal.WithWarnings(result, warnings...)
+}
+
+// Found creates an OptionResult for an operation that succeeded and found value.
+//
+// Example:
+//
+//	func (r *Repo) FindUser(id int) OptionResult[User] {
+//	    row, err := r.db.QueryUser(id)
+//	    switch {
+//	    case err != nil:
+//	        return Failed[User](err)
+//	    case row == nil:
+//	        return Absent[User]()
+//	    default:
+//	        return Found(row.User())
+//	    }
+//	}
+func Found[T any](value T) OptionResult[T] {
+	return internal.Found(value)
+}
+
+// Absent creates an OptionResult for an operation that succeeded and found nothing.
+//
+// Example:
+//
+//	user := Absent[User]()
+//	user.IsNone() // true
+//	user.OrNotFound(ErrNotFound).UnwrapErr() // ErrNotFound
+func Absent[T any]() OptionResult[T] {
+	return internal.Absent[T]()
+}
+
+// Failed creates an OptionResult for an operation that failed with err.
+//
+// Example:
+//
+//	user := Failed[User](errors.New("connection refused"))
+//	user.IsError() // true
+//	user.Result().UnwrapErr() // errors.New("connection refused")
+func Failed[T any](err error) OptionResult[T] {
+	return internal.Failed[T](err)
+}
+
+// Success creates an Outcome for an operation that completed.
+//
+// Example:
+//
+//	func (s *Store) Put(u User) Outcome {
+//	    if err := s.db.Save(u); err != nil {
+//	        return Failure(err)
+//	    }
+//	    return Success()
+//	}
+func Success() Outcome {
+	return internal.Success()
+}
+
+// Failure creates an Outcome for an operation that failed with err.
+// A nil err creates a Success.
+//
+// Example:
+//
+//	outcome := Failure(errors.New("disk full"))
+//	outcome.IsFailure() // true
+//	outcome.Error() // errors.New("disk full")
+func Failure(err error) Outcome {
+	return internal.OutcomeFromError(err)
+}
@@ -0,0 +1,90 @@
+package extension
+
+import (
+	"errors"
+
+	"codeberg.org/yaadata/opt/core"
+	"codeberg.org/yaadata/opt/internal"
+)
+
+// Resource pairs how to acquire a resource with how to release it.
+type Resource[R any] struct {
+	Acquire func() core.Result[R]
+	Release func(R) error
+}
+
+// Bracket acquires a resource, uses it and always releases it.
+//
+// If acquire returns Err, use and release are not called and the error is returned.
+// Otherwise release is called once use returns, even if use panics.
+// A panic in use is recovered and returned as Err(*PanicError).
+// A release error is combined with the error from use through errors.Join;
+// if use was Ok, the release error alone is returned as Err.
+//
+// Example:
+//
+//	result := Bracket(
+//	    func() core.Result[*os.File] { return ResultFromReturn(os.Open(path)) },
+//	    func(f *os.File) core.Result[[]byte] { return ResultFromReturn(io.ReadAll(f)) },
+//	    func(f *os.File) error { return f.Close() },
+//	)
+func Bracket[R, T any](
+	acquire func() core.Result[R],
+	use func(R) core.Result[T],
+	release func(R) error,
+) core.Result[T] {
+	resource := acquire()
+	if resource.IsError() {
+		return internal.ErrFrom[T](resource)
+	}
+	r := resource.Unwrap()
+	return withRelease(CatchResult(func() core.Result[T] { return use(r) }), func() error { return release(r) })
+}
+
+// BracketAll acquires every resource in order, uses them together and
+// releases them in reverse order of acquisition.
+//
+// If an acquisition fails, the resources acquired before it are released in
+// reverse order and the acquisition error is returned joined with any
+// release errors. Otherwise it behaves like Bracket: every release runs even
+// if use panics or a release fails, and all errors are combined with errors.Join.
+//
+// Example:
+//
+//	result := BracketAll(
+//	    func(conns []*sql.Conn) core.Result[int] { return copyRows(conns[0], conns[1]) },
+//	    Resource[*sql.Conn]{Acquire: source.Conn, Release: (*sql.Conn).Close},
+//	    Resource[*sql.Conn]{Acquire: target.Conn, Release: (*sql.Conn).Close},
+//	)
+func BracketAll[R, T any](use func([]R) core.Result[T], resources ...Resource[R]) core.Result[T] {
+	acquired := make([]R, 0, len(resources))
+	releaseAll := func() error {
+		var errs []error
+		for i := len(acquired) - 1; i >= 0; i-- {
+			if err := resources[i].Release(acquired[i]); err != nil {
+				errs = append(errs, err)
+			}
+		}
+		return errors.Join(errs...)
+	}
+	for _, resource := range resources {
+		res := resource.Acquire()
+		if res.IsError() {
+			return internal.Err[T](errors.Join(res.UnwrapErr(), releaseAll()))
+		}
+		acquired = append(acquired, res.Unwrap())
+	}
+	return withRelease(CatchResult(func() core.Result[T] { return use(acquired) }), releaseAll)
+}
+
+// withRelease runs release and folds its error into result.
+func withRelease[T any](result core.Result[T], release func() error) core.Result[T] {
+	err := release()
+	if err == nil {
+		return result
+	}
+	if result.IsOk() {
+		return internal.Err[T](err)
+	}
+	return internal.Err[T](errors.Join(result.UnwrapErr(), err))
+}
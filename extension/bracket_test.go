@@ -0,0 +1,173 @@
+package extension_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/shoenig/test/must"
+
+	"codeberg.org/yaadata/opt/core"
+	"codeberg.org/yaadata/opt/extension"
+	"codeberg.org/yaadata/opt/internal"
+)
+
+type handle struct {
+	name string
+	log  *[]string
+}
+
+func (h handle) resource(acquireErr, releaseErr error) extension.Resource[handle] {
+	return extension.Resource[handle]{
+		Acquire: func() core.Result[handle] {
+			if acquireErr != nil {
+				return internal.Err[handle](acquireErr)
+			}
+			*h.log = append(*h.log, "acquire "+h.name)
+			return internal.Ok(h)
+		},
+		Release: func(r handle) error {
+			*h.log = append(*h.log, "release "+r.name)
+			return releaseErr
+		},
+	}
+}
+
+func TestBracket(t *testing.T) {
+	t.Parallel()
+	t.Run("Use result is returned after release", func(t *testing.T) {
+		t.Parallel()
+		// [A]rrange
+		var log []string
+		r := handle{name: "file", log: &log}.resource(nil, nil)
+		// [A]ct
+		actual := extension.Bracket(r.Acquire, func(h handle) core.Result[string] {
+			log = append(log, "use "+h.name)
+			return internal.Ok("EXPECTED")
+		}, r.Release)
+		// [A]ssert
+		must.Eq(t, "EXPECTED", actual.Unwrap())
+		must.Eq(t, []string{"acquire file", "use file", "release file"}, log)
+	})
+
+	t.Run("Acquire error skips use and release", func(t *testing.T) {
+		t.Parallel()
+		// [A]rrange
+		var log []string
+		expected := errors.New("acquire")
+		r := handle{name: "file", log: &log}.resource(expected, nil)
+		// [A]ct
+		actual := extension.Bracket(r.Acquire, func(_ handle) core.Result[string] {
+			log = append(log, "use")
+			return internal.Ok("UNEXPECTED")
+		}, r.Release)
+		// [A]ssert
+		must.Eq(t, expected, actual.UnwrapErr())
+		must.SliceEmpty(t, log)
+	})
+
+	t.Run("Release error turns Ok into Err", func(t *testing.T) {
+		t.Parallel()
+		// [A]rrange
+		var log []string
+		expected := errors.New("close")
+		r := handle{name: "file", log: &log}.resource(nil, expected)
+		// [A]ct
+		actual := extension.Bracket(r.Acquire, func(_ handle) core.Result[string] {
+			return internal.Ok("value")
+		}, r.Release)
+		// [A]ssert
+		must.Eq(t, expected, actual.UnwrapErr())
+	})
+
+	t.Run("Release error is joined with use error", func(t *testing.T) {
+		t.Parallel()
+		// [A]rrange
+		var log []string
+		useErr := errors.New("read")
+		releaseErr := errors.New("close")
+		r := handle{name: "file", log: &log}.resource(nil, releaseErr)
+		// [A]ct
+		actual := extension.Bracket(r.Acquire, func(_ handle) core.Result[string] {
+			return internal.Err[string](useErr)
+		}, r.Release)
+		// [A]ssert
+		must.ErrorIs(t, actual.UnwrapErr(), useErr)
+		must.ErrorIs(t, actual.UnwrapErr(), releaseErr)
+	})
+
+	t.Run("Panic in use is recovered and still releases", func(t *testing.T) {
+		t.Parallel()
+		// [A]rrange
+		var log []string
+		cause := errors.New("nil map")
+		r := handle{name: "file", log: &log}.resource(nil, nil)
+		// [A]ct
+		actual := extension.Bracket(r.Acquire, func(_ handle) core.Result[string] {
+			panic(cause)
+		}, r.Release)
+		// [A]ssert
+		must.Eq(t, []string{"acquire file", "release file"}, log)
+		var panicErr *extension.PanicError
+		must.True(t, errors.As(actual.UnwrapErr(), &panicErr))
+		must.Eq(t, any(cause), panicErr.Value)
+		must.ErrorIs(t, actual.UnwrapErr(), cause)
+		must.StrContains(t, string(panicErr.Stack), "bracket_test.go")
+	})
+}
+
+func TestBracketAll(t *testing.T) {
+	t.Parallel()
+	t.Run("Resources are released in reverse order", func(t *testing.T) {
+		t.Parallel()
+		// [A]rrange
+		var log []string
+		a := handle{name: "a", log: &log}.resource(nil, nil)
+		b := handle{name: "b", log: &log}.resource(nil, nil)
+		// [A]ct
+		actual := extension.BracketAll(func(hs []handle) core.Result[int] {
+			log = append(log, "use")
+			return internal.Ok(len(hs))
+		}, a, b)
+		// [A]ssert
+		must.Eq(t, 2, actual.Unwrap())
+		must.Eq(t, []string{"acquire a", "acquire b", "use", "release b", "release a"}, log)
+	})
+
+	t.Run("Failed acquisition releases what was acquired", func(t *testing.T) {
+		t.Parallel()
+		// [A]rrange
+		var log []string
+		expected := errors.New("acquire c")
+		a := handle{name: "a", log: &log}.resource(nil, nil)
+		b := handle{name: "b", log: &log}.resource(nil, nil)
+		c := handle{name: "c", log: &log}.resource(expected, nil)
+		// [A]ct
+		actual := extension.BracketAll(func(_ []handle) core.Result[int] {
+			log = append(log, "use")
+			return internal.Ok(0)
+		}, a, b, c)
+		// [A]ssert
+		must.ErrorIs(t, actual.UnwrapErr(), expected)
+		must.Eq(t, []string{"acquire a", "acquire b", "release b", "release a"}, log)
+	})
+
+	t.Run("Every release runs and errors are joined", func(t *testing.T) {
+		t.Parallel()
+		// [A]rrange
+		var log []string
+		errA := errors.New("close a")
+		errB := errors.New("close b")
+		a := handle{name: "a", log: &log}.resource(nil, errA)
+		b := handle{name: "b", log: &log}.resource(nil, errB)
+		// [A]ct
+		actual := extension.BracketAll(func(_ []handle) core.Result[int] {
+			panic("boom")
+		}, a, b)
+		// [A]ssert
+		must.Eq(t, []string{"acquire a", "acquire b", "release b", "release a"}, log)
+		var panicErr *extension.PanicError
+		must.True(t, errors.As(actual.UnwrapErr(), &panicErr))
+		must.ErrorIs(t, actual.UnwrapErr(), errA)
+		must.ErrorIs(t, actual.UnwrapErr(), errB)
+	})
+}
@@ -0,0 +1,120 @@
+package extension
+
+import (
+	"fmt"
+	"maps"
+	"reflect"
+
+	"codeberg.org/yaadata/opt/core"
+	"codeberg.org/yaadata/opt/internal"
+)
+
+// DoEnv holds the values bound by the earlier steps of a DoBlock.
+// Values are read with DoGet.
+type DoEnv struct {
+	values map[string]any
+}
+
+// DoStepError is the error of a DoBlock whose step returned Err.
+// It names the step and wraps the error the step returned.
+type DoStepError struct {
+	Step string
+	Err  error
+}
+
+func (e *DoStepError) Error() string {
+	return fmt.Sprintf("%s: %v", e.Step, e.Err)
+}
+
+func (e *DoStepError) Unwrap() error {
+	return e.Err
+}
+
+// DoBlock runs named steps in order, binding the Ok value of each so later
+// steps can read it. The first step that returns Err stops the block and
+// every later step is skipped. A DoBlock is created with Do and is immutable;
+// Bind returns a new block.
+type DoBlock struct {
+	result core.Result[DoEnv]
+}
+
+// Do starts an empty DoBlock.
+//
+// Example:
+//
+//	block := Do().
+//	    Bind("user", DoStep(func(env DoEnv) core.Result[User] {
+//	        return fetchUser(id)
+//	    })).
+//	    Bind("org", DoStep(func(env DoEnv) core.Result[Org] {
+//	        return fetchOrg(DoGet[User](env, "user").OrgID)
+//	    }))
+//	summary := DoYield(block, func(env DoEnv) Summary {
+//	    return Summary{User: DoGet[User](env, "user"), Org: DoGet[Org](env, "org")}
+//	})
+//	// If fetchOrg fails: Err(*DoStepError{Step: "org", Err: ...})
+func Do() DoBlock {
+	return DoBlock{result: internal.Ok(DoEnv{})}
+}
+
+// DoStep adapts a typed step for DoBlock.Bind.
+func DoStep[T any](fn func(env DoEnv) core.Result[T]) func(env DoEnv) core.Result[any] {
+	return func(env DoEnv) core.Result[any] {
+		return internal.ResultMap(fn(env), func(value T) any {
+			return value
+		})
+	}
+}
+
+// Bind runs step with the values bound so far and binds its Ok value to name.
+// If step returns Err, the block fails with a *DoStepError naming the step.
+// If the block has already failed, step is not called.
+// Bind panics if name is already bound.
+func (b DoBlock) Bind(name string, step func(env DoEnv) core.Result[any]) DoBlock {
+	return DoBlock{result: ResultAndThen(b.result, func(env DoEnv) core.Result[DoEnv] {
+		if _, ok := env.values[name]; ok {
+			panic(fmt.Sprintf("extension: step %q is bound twice", name))
+		}
+		bound := step(env).MapErr(func(err error) error {
+			return &DoStepError{Step: name, Err: err}
+		})
+		return internal.ResultMap(bound, func(value any) DoEnv {
+			return env.with(name, value)
+		})
+	})}
+}
+
+// Result returns Ok with the values bound by every step, or the Err of the
+// step that failed.
+func (b DoBlock) Result() core.Result[DoEnv] {
+	return b.result
+}
+
+// DoYield builds the final value of a DoBlock from the values bound by its
+// steps. If a step failed, fn is not called and the Err of that step is returned.
+func DoYield[T any](block DoBlock, fn func(env DoEnv) T) core.Result[T] {
+	return internal.ResultMap(block.result, fn)
+}
+
+// DoGet returns the value bound to name.
+// It panics if no step with that name has run or the value is not of type T.
+func DoGet[T any](env DoEnv, name string) T {
+	value, ok := env.values[name]
+	if !ok {
+		panic(fmt.Sprintf("extension: step %q is not bound", name))
+	}
+	typed, ok := value.(T)
+	if !ok {
+		panic(fmt.Sprintf("extension: step %q is bound to %T, not %s", name, value, reflect.TypeFor[T]()))
+	}
+	return typed
+}
+
+func (env DoEnv) with(name string, value any) DoEnv {
+	values := maps.Clone(env.values)
+	if values == nil {
+		values = make(map[string]any, 1)
+	}
+	values[name] = value
+	return DoEnv{values: values}
+}
@@ -0,0 +1,107 @@
+package extension_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/shoenig/test/must"
+
+	"codeberg.org/yaadata/opt/core"
+	"codeberg.org/yaadata/opt/extension"
+	"codeberg.org/yaadata/opt/internal"
+)
+
+func TestDo(t *testing.T) {
+	t.Parallel()
+	type User struct {
+		Name  string
+		OrgID int
+	}
+	fetchUser := extension.DoStep(func(env extension.DoEnv) core.Result[User] {
+		return internal.Ok(User{Name: "Alice", OrgID: 7})
+	})
+	fetchOrg := extension.DoStep(func(env extension.DoEnv) core.Result[string] {
+		if extension.DoGet[User](env, "user").OrgID == 7 {
+			return internal.Ok("Acme")
+		}
+		return internal.Err[string](errors.New("org not found"))
+	})
+
+	t.Run("Every step Ok yields the final value", func(t *testing.T) {
+		t.Parallel()
+		// [A]rrange
+		block := extension.Do().
+			Bind("user", fetchUser).
+			Bind("org", fetchOrg)
+		// [A]ct
+		actual := extension.DoYield(block, func(env extension.DoEnv) string {
+			return extension.DoGet[User](env, "user").Name + "@" + extension.DoGet[string](env, "org")
+		})
+		// [A]ssert
+		must.Eq(t, "Alice@Acme", actual.Unwrap())
+	})
+
+	t.Run("First Err names the failed step and skips the rest", func(t *testing.T) {
+		t.Parallel()
+		// [A]rrange
+		cause := errors.New("connection refused")
+		called := false
+		block := extension.Do().
+			Bind("user", fetchUser).
+			Bind("org", extension.DoStep(func(env extension.DoEnv) core.Result[string] {
+				return internal.Err[string](cause)
+			})).
+			Bind("billing", extension.DoStep(func(env extension.DoEnv) core.Result[int] {
+				called = true
+				return internal.Ok(1)
+			}))
+		// [A]ct
+		actual := extension.DoYield(block, func(env extension.DoEnv) string {
+			return extension.DoGet[string](env, "org")
+		})
+		// [A]ssert
+		must.False(t, called)
+		must.EqError(t, actual.UnwrapErr(), "org: connection refused")
+		must.ErrorIs(t, actual.UnwrapErr(), cause)
+		stepErr := extension.ErrorAs[*extension.DoStepError](actual).Unwrap()
+		must.Eq(t, "org", stepErr.Step)
+	})
+
+	t.Run("Blocks are immutable", func(t *testing.T) {
+		t.Parallel()
+		// [A]rrange
+		base := extension.Do().Bind("user", fetchUser)
+		// [A]ct
+		first := base.Bind("org", fetchOrg)
+		second := base.Bind("org", extension.DoStep(func(env extension.DoEnv) core.Result[string] {
+			return internal.Ok("Other")
+		}))
+		// [A]ssert
+		must.Eq(t, "Acme", extension.DoGet[string](first.Result().Unwrap(), "org"))
+		must.Eq(t, "Other", extension.DoGet[string](second.Result().Unwrap(), "org"))
+	})
+
+	t.Run("Binding a name twice panics", func(t *testing.T) {
+		t.Parallel()
+		// [A]rrange
+		block := extension.Do().Bind("user", fetchUser)
+		// [A]ct
+		actual := extension.Catch(func() extension.DoBlock {
+			return block.Bind("user", fetchUser)
+		})
+		// [A]ssert
+		must.True(t, actual.IsError())
+	})
+
+	t.Run("DoGet panics on a wrong type", func(t *testing.T) {
+		t.Parallel()
+		// [A]rrange
+		env := extension.Do().Bind("user", fetchUser).Result().Unwrap()
+		// [A]ct
+		actual := extension.Catch(func() int {
+			return extension.DoGet[int](env, "user")
+		})
+		// [A]ssert
+		must.StrContains(t, actual.UnwrapErr().Error(), `step "user" is bound to extension_test.User, not int`)
+	})
+}
@@ -0,0 +1,49 @@
+package extension
+
+import (
+	"errors"
+
+	"codeberg.org/yaadata/opt/core"
+	"codeberg.org/yaadata/opt/internal"
+)
+
+// ErrorAs returns Some with the first error in the Err's chain that matches
+// type E, as found by errors.As. It returns None if the result is Ok or no
+// error in the chain matches.
+//
+// Example:
+//
+//	result := Err[string](fmt.Errorf("loading: %w", &fs.PathError{Op: "open"}))
+//	pathErr := ErrorAs[*fs.PathError](result) // Some(&fs.PathError{Op: "open"})
+//
+//	result := Ok("value")
+//	pathErr := ErrorAs[*fs.PathError](result) // None
+func ErrorAs[E error, T any](res core.Result[T]) core.Option[E] {
+	if res.IsOk() {
+		return internal.None[E]()
+	}
+	var target E
+	if errors.As(res.UnwrapErr(), &target) {
+		return internal.Some(target)
+	}
+	return internal.None[E]()
+}
+
+// RecoverAs calls fn with the first error in the Err's chain that matches
+// type E, as found by errors.As, to produce an alternative Result. If the
+// result is Ok or no error in the chain matches, it is returned unchanged.
+//
+// Example:
+//
+//	result := Err[int](&strconv.NumError{Func: "Atoi", Num: "x", Err: strconv.ErrSyntax})
+//	recovered := RecoverAs(result, func(e *strconv.NumError) core.Result[int] {
+//	    return Ok(0)
+//	})
+//	recovered.Unwrap() // 0
+func RecoverAs[E error, T any](res core.Result[T], fn func(err E) core.Result[T]) core.Result[T] {
+	matched := ErrorAs[E](res)
+	if matched.IsSome() {
+		return fn(matched.Unwrap())
+	}
+	return res
+}
@@ -0,0 +1,92 @@
+package extension_test
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"testing"
+
+	"github.com/shoenig/test/must"
+
+	"codeberg.org/yaadata/opt/core"
+	"codeberg.org/yaadata/opt/extension"
+	"codeberg.org/yaadata/opt/internal"
+)
+
+func TestErrorAs(t *testing.T) {
+	t.Parallel()
+	t.Run("Err with matching type in chain is Some", func(t *testing.T) {
+		t.Parallel()
+		// [A]rrange
+		expected := &fs.PathError{Op: "open", Path: "config.yaml", Err: fs.ErrNotExist}
+		result := internal.Err[string](fmt.Errorf("loading: %w", expected))
+		// [A]ct
+		actual := extension.ErrorAs[*fs.PathError](result)
+		// [A]ssert
+		must.True(t, actual.IsSome())
+		must.Eq(t, expected, actual.Unwrap())
+	})
+
+	t.Run("Err without matching type is None", func(t *testing.T) {
+		t.Parallel()
+		// [A]rrange
+		result := internal.Err[string](errors.New("error"))
+		// [A]ct
+		actual := extension.ErrorAs[*fs.PathError](result)
+		// [A]ssert
+		must.True(t, actual.IsNone())
+	})
+
+	t.Run("Ok is None", func(t *testing.T) {
+		t.Parallel()
+		// [A]rrange
+		result := internal.Ok("value")
+		// [A]ct
+		actual := extension.ErrorAs[*fs.PathError](result)
+		// [A]ssert
+		must.True(t, actual.IsNone())
+	})
+}
+
+func TestRecoverAs(t *testing.T) {
+	t.Parallel()
+	t.Run("Err with matching type is recovered", func(t *testing.T) {
+		t.Parallel()
+		// [A]rrange
+		result := internal.Err[string](&fs.PathError{Op: "open", Path: "config.yaml", Err: fs.ErrNotExist})
+		// [A]ct
+		actual := extension.RecoverAs(result, func(err *fs.PathError) core.Result[string] {
+			return internal.Ok(err.Path)
+		})
+		// [A]ssert
+		must.Eq(t, "config.yaml", actual.Unwrap())
+	})
+
+	t.Run("Err without matching type passes through", func(t *testing.T) {
+		t.Parallel()
+		// [A]rrange
+		expected := errors.New("error")
+		result := internal.Err[string](expected)
+		called := false
+		// [A]ct
+		actual := extension.RecoverAs(result, func(err *fs.PathError) core.Result[string] {
+			called = true
+			return internal.Ok(err.Path)
+		})
+		// [A]ssert
+		must.False(t, called)
+		must.Eq(t, expected, actual.UnwrapErr())
+	})
+
+	t.Run("Ok passes through", func(t *testing.T) {
+		t.Parallel()
+		// [A]rrange
+		result := internal.Ok("value")
+		// [A]ct
+		actual := extension.RecoverAs(result, func(err *fs.PathError) core.Result[string] {
+			return internal.Ok("recovered")
+		})
+		// [A]ssert
+		must.Eq(t, "value", actual.Unwrap())
+	})
+}
@@ -0,0 +1,56 @@
+package extension
+
+import (
+	"slices"
+
+	"codeberg.org/yaadata/opt/internal"
+)
+
+// Field is a key/value pair attached to an error with Result.WithField.
+type Field struct {
+	Key   string
+	Value any
+}
+
+// Fields returns every field attached to err or to any error it wraps, in the
+// order they were attached: fields closest to the original error come first.
+// Keys attached more than once appear once per attachment.
+// Joined errors are searched depth-first in order.
+//
+// Example:
+//
+//	result := loadUser(42).
+//	    WithField("user_id", 42).
+//	    Context("rendering profile").
+//	    WithField("request_id", reqID)
+//	for _, field := range Fields(result.UnwrapErr()) {
+//	    attrs = append(attrs, slog.Any(field.Key, field.Value))
+//	}
+func Fields(err error) []Field {
+	var fields []Field
+	collectFields(err, &fields)
+	slices.Reverse(fields)
+	return fields
+}
+
+// collectFields walks the error tree outermost first.
+func collectFields(err error, fields *[]Field) {
+	for err != nil {
+		if field, ok := err.(*internal.FieldError); ok {
+			*fields = append(*fields, Field{Key: field.Key, Value: field.Value})
+		}
+		switch wrapped := err.(type) {
+		case interface{ Unwrap() error }:
+			err = wrapped.Unwrap()
+		case interface{ Unwrap() []error }:
+			// reversed so that, after the final reverse, joined errors read in order
+			errs := wrapped.Unwrap()
+			for i := len(errs) - 1; i >= 0; i-- {
+				collectFields(errs[i], fields)
+			}
+			return
+		default:
+			return
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package extension_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/shoenig/test/must"
+
+	"codeberg.org/yaadata/opt/extension"
+	"codeberg.org/yaadata/opt/internal"
+)
+
+func TestFields(t *testing.T) {
+	t.Parallel()
+	t.Run("Fields are returned in the order they were attached", func(t *testing.T) {
+		t.Parallel()
+		// [A]rrange
+		result := internal.Err[int](errors.New("not found")).
+			WithField("user_id", 42).
+			Context("rendering profile").
+			WithField("request_id", "r-1")
+		// [A]ct
+		actual := extension.Fields(result.UnwrapErr())
+		// [A]ssert
+		must.Eq(t, []extension.Field{{Key: "user_id", Value: 42}, {Key: "request_id", Value: "r-1"}}, actual)
+	})
+
+	t.Run("Fields survive foreign wrapping", func(t *testing.T) {
+		t.Parallel()
+		// [A]rrange
+		inner := internal.Err[int](errors.New("timeout")).WithField("attempt", 3).UnwrapErr()
+		err := fmt.Errorf("calling billing: %w", inner)
+		// [A]ct
+		actual := extension.Fields(err)
+		// [A]ssert
+		must.Eq(t, []extension.Field{{Key: "attempt", Value: 3}}, actual)
+	})
+
+	t.Run("Joined errors are searched in order", func(t *testing.T) {
+		t.Parallel()
+		// [A]rrange
+		a := internal.Err[int](errors.New("a")).WithField("a0", 0).WithField("a1", 1).UnwrapErr()
+		b := internal.Err[int](errors.New("b")).WithField("b0", 0).UnwrapErr()
+		err := internal.Err[int](errors.Join(a, b)).WithField("outer", true).UnwrapErr()
+		// [A]ct
+		actual := extension.Fields(err)
+		// [A]ssert
+		must.Eq(t, []extension.Field{
+			{Key: "a0", Value: 0},
+			{Key: "a1", Value: 1},
+			{Key: "b0", Value: 0},
+			{Key: "outer", Value: true},
+		}, actual)
+	})
+
+	t.Run("Error without fields has none", func(t *testing.T) {
+		t.Parallel()
+		// [A]ct
+		actual := extension.Fields(errors.New("plain"))
+		// [A]ssert
+		must.SliceEmpty(t, actual)
+	})
+}
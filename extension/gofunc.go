@@ -0,0 +1,45 @@
+package extension
+
+import "codeberg.org/yaadata/opt/core"
+
+// GoFunc adapts a Result-returning function to Go's (value, error)
+// convention, for passing it to code that does not know about Result.
+// GoFunc1 to GoFunc3 do the same for functions that take arguments.
+//
+// Example:
+//
+//	var load func() (Config, error) = GoFunc(loadConfig)
+func GoFunc[T any](fn func() core.Result[T]) func() (T, error) {
+	return func() (T, error) {
+		return fn().Get()
+	}
+}
+
+// GoFunc1 is GoFunc for functions that take one argument.
+//
+// Example:
+//
+//	users, err := cache.GetOrLoad(id, GoFunc1(fetchUser)) // wants func(int) (User, error)
+func GoFunc1[A, T any](fn func(A) core.Result[T]) func(A) (T, error) {
+	return func(a A) (T, error) {
+		return fn(a).Get()
+	}
+}
+
+// GoFunc2 is GoFunc for functions that take two arguments.
+//
+// Example:
+//
+//	var fetch func(context.Context, int) (User, error) = GoFunc2(fetchUser)
+func GoFunc2[A, B, T any](fn func(A, B) core.Result[T]) func(A, B) (T, error) {
+	return func(a A, b B) (T, error) {
+		return fn(a, b).Get()
+	}
+}
+
+// GoFunc3 is GoFunc for functions that take three arguments.
+func GoFunc3[A, B, C, T any](fn func(A, B, C) core.Result[T]) func(A, B, C) (T, error) {
+	return func(a A, b B, c C) (T, error) {
+		return fn(a, b, c).Get()
+	}
+}
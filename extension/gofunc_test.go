@@ -0,0 +1,77 @@
+package extension_test
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/shoenig/test/must"
+
+	"codeberg.org/yaadata/opt/core"
+	"codeberg.org/yaadata/opt/extension"
+	"codeberg.org/yaadata/opt/internal"
+)
+
+func TestGoFunc(t *testing.T) {
+	t.Parallel()
+	t.Run("Ok returns value and nil error", func(t *testing.T) {
+		t.Parallel()
+		// [A]rrange
+		fn := extension.GoFunc(func() core.Result[int] {
+			return internal.Ok(3)
+		})
+		// [A]ct
+		value, err := fn()
+		// [A]ssert
+		must.NoError(t, err)
+		must.Eq(t, 3, value)
+	})
+
+	t.Run("Err returns zero value and error", func(t *testing.T) {
+		t.Parallel()
+		// [A]rrange
+		cause := errors.New("error")
+		fn := extension.GoFunc(func() core.Result[int] {
+			return internal.Err[int](cause)
+		})
+		// [A]ct
+		value, err := fn()
+		// [A]ssert
+		must.Eq(t, cause, err)
+		must.Eq(t, 0, value)
+	})
+}
+
+func TestGoFuncN(t *testing.T) {
+	t.Parallel()
+	t.Run("Arguments are passed through", func(t *testing.T) {
+		t.Parallel()
+		// [A]rrange
+		one := extension.GoFunc1(parseInt)
+		two := extension.GoFunc2(func(a, b int) core.Result[int] { return internal.Ok(a + b) })
+		three := extension.GoFunc3(func(a, b, c int) core.Result[string] {
+			return internal.Ok(strconv.Itoa(a + b + c))
+		})
+		// [A]ct
+		parsed, parseErr := one("42")
+		sum, sumErr := two(1, 2)
+		joined, joinErr := three(1, 2, 3)
+		// [A]ssert
+		must.NoError(t, parseErr)
+		must.NoError(t, sumErr)
+		must.NoError(t, joinErr)
+		must.Eq(t, 42, parsed)
+		must.Eq(t, 3, sum)
+		must.Eq(t, "6", joined)
+	})
+
+	t.Run("Err is returned as error", func(t *testing.T) {
+		t.Parallel()
+		// [A]rrange
+		one := extension.GoFunc1(parseInt)
+		// [A]ct
+		_, err := one("x")
+		// [A]ssert
+		must.Error(t, err)
+	})
+}
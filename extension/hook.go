@@ -0,0 +1,26 @@
+package extension
+
+import (
+	"codeberg.org/yaadata/opt/core"
+	"codeberg.org/yaadata/opt/internal"
+)
+
+// OnUnwrapPanic registers a process-wide hook that runs right before
+// Unwrap, Expect, UnwrapErr or ExpectErr panics on any Option or Result.
+// The hook receives the *core.UnwrapError about to be panicked with,
+// including its Stack. It returns a function that removes the hook;
+// calling it more than once has no further effect.
+//
+// Hooks run on the panicking goroutine in registration order and must be
+// safe for concurrent use. While no hook is registered, panicking costs
+// nothing extra and the stack trace is not captured.
+//
+// Example:
+//
+//	remove := OnUnwrapPanic(func(err *core.UnwrapError) {
+//	    crashReporter.Record(err.Method, err.Message, err.Err, err.Stack)
+//	})
+//	defer remove()
+func OnUnwrapPanic(hook func(*core.UnwrapError)) (remove func()) {
+	return internal.RegisterUnwrapHook(hook)
+}
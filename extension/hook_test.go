@@ -0,0 +1,68 @@
+package extension_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/shoenig/test/must"
+
+	"codeberg.org/yaadata/opt/core"
+	"codeberg.org/yaadata/opt/extension"
+	"codeberg.org/yaadata/opt/internal"
+)
+
+// TestOnUnwrapPanic is not parallel because hooks are process-wide.
+func TestOnUnwrapPanic(t *testing.T) {
+	t.Run("Hook receives the panic details before it propagates", func(t *testing.T) {
+		// [A]rrange
+		cause := errors.New("connection refused")
+		var received []*core.UnwrapError
+		remove := extension.OnUnwrapPanic(func(err *core.UnwrapError) {
+			received = append(received, err)
+		})
+		defer remove()
+		// [A]ct
+		actual := extension.Catch(func() int {
+			return internal.Err[int](cause).Expect("loading config")
+		})
+		// [A]ssert
+		must.SliceLen(t, 1, received)
+		hooked := received[0]
+		must.Eq(t, "Result.Expect", hooked.Method)
+		must.Eq(t, "loading config", hooked.Message)
+		must.Eq(t, cause, hooked.Err)
+		must.StrContains(t, string(hooked.Stack), "hook_test.go")
+		var panicked *core.UnwrapError
+		must.True(t, errors.As(actual.UnwrapErr(), &panicked))
+		must.Eq(t, hooked, panicked)
+	})
+
+	t.Run("Every hook runs in registration order", func(t *testing.T) {
+		// [A]rrange
+		var order []string
+		removeA := extension.OnUnwrapPanic(func(err *core.UnwrapError) { order = append(order, "a:"+err.Method) })
+		defer removeA()
+		removeB := extension.OnUnwrapPanic(func(err *core.UnwrapError) { order = append(order, "b:"+err.Method) })
+		defer removeB()
+		// [A]ct
+		extension.Catch(func() int { return internal.None[int]().Unwrap() })
+		extension.Catch(func() error { return internal.Ok(1).UnwrapErr() })
+		// [A]ssert
+		must.Eq(t, []string{"a:Option.Unwrap", "b:Option.Unwrap", "a:Result.UnwrapErr", "b:Result.UnwrapErr"}, order)
+	})
+
+	t.Run("Removed hook no longer runs", func(t *testing.T) {
+		// [A]rrange
+		calls := 0
+		remove := extension.OnUnwrapPanic(func(_ *core.UnwrapError) { calls++ })
+		remove()
+		remove()
+		// [A]ct
+		actual := extension.Catch(func() int { return internal.None[int]().Expect("missing") })
+		// [A]ssert
+		must.Zero(t, calls)
+		var panicked *core.UnwrapError
+		must.True(t, errors.As(actual.UnwrapErr(), &panicked))
+		must.Nil(t, panicked.Stack)
+	})
+}
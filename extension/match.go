@@ -0,0 +1,154 @@
+package extension
+
+import (
+	"errors"
+
+	"codeberg.org/yaadata/opt/core"
+	"codeberg.org/yaadata/opt/internal"
+)
+
+// MatchOption folds an Option into a single value of type V by calling onSome
+// with the contained value or onNone.
+//
+// Example:
+//
+//	opt := Some("Alice")
+//	greeting := MatchOption(opt,
+//	    func(name string) string { return "Hello, " + name },
+//	    func() string { return "Hello, stranger" },
+//	) // "Hello, Alice"
+func MatchOption[T, V any](opt core.Option[T], onSome func(value T) V, onNone func() V) V {
+	if opt.IsSome() {
+		return onSome(opt.Unwrap())
+	}
+	return onNone()
+}
+
+// MatchResult folds a Result into a single value of type V by calling onOk
+// with the Ok value or onErr with the error.
+//
+// Example:
+//
+//	result := Ok(3)
+//	status := MatchResult(result,
+//	    func(v int) int { return http.StatusOK },
+//	    func(e error) int { return http.StatusInternalServerError },
+//	) // 200
+func MatchResult[T, V any](res core.Result[T], onOk func(value T) V, onErr func(err error) V) V {
+	if res.IsOk() {
+		return onOk(res.Unwrap())
+	}
+	return onErr(res.UnwrapErr())
+}
+
+// ErrCase is a single branch of an ErrMatcher. It returns Some with the
+// replacement Result if it handles the error, otherwise None.
+// Cases are built with CaseIs and CaseAs.
+type ErrCase[T any] func(err error) core.Option[core.Result[T]]
+
+// CaseIs builds a branch that handles errors matching target with errors.Is.
+//
+// Example:
+//
+//	CaseIs(ErrNotFound, func(e error) core.Result[User] { return Ok(Guest) })
+func CaseIs[T any](target error, fn func(err error) core.Result[T]) ErrCase[T] {
+	return func(err error) core.Option[core.Result[T]] {
+		if errors.Is(err, target) {
+			return internal.Some(fn(err))
+		}
+		return internal.None[core.Result[T]]()
+	}
+}
+
+// CaseAs builds a branch that handles errors whose chain holds an error of
+// type E, as found by errors.As.
+//
+// Example:
+//
+//	CaseAs(func(e *ValidationError) core.Result[User] {
+//	    return Err[User](fmt.Errorf("invalid %s", e.Field))
+//	})
+func CaseAs[E error, T any](fn func(err E) core.Result[T]) ErrCase[T] {
+	return func(err error) core.Option[core.Result[T]] {
+		var target E
+		if errors.As(err, &target) {
+			return internal.Some(fn(target))
+		}
+		return internal.None[core.Result[T]]()
+	}
+}
+
+// ErrMatcher routes an Err to the first branch that handles it.
+// Branches are tried in the order they were added and an Ok result skips all
+// of them. An ErrMatcher is created with MatchErr and is immutable; every
+// branch returns a new matcher.
+type ErrMatcher[T any] struct {
+	result  core.Result[T]
+	handled core.Option[core.Result[T]]
+}
+
+// MatchErr starts matching on the error of res.
+//
+// Example:
+//
+//	user := MatchErr(fetchUser(id)).
+//	    Is(ErrNotFound, func(e error) core.Result[User] { return Ok(Guest) }).
+//	    Case(CaseAs(func(e *ValidationError) core.Result[User] { return Err[User](e) })).
+//	    Otherwise(func(e error) core.Result[User] { return Err[User](fmt.Errorf("fetching user: %w", e)) })
+func MatchErr[T any](res core.Result[T]) ErrMatcher[T] {
+	return ErrMatcher[T]{
+		result:  res,
+		handled: internal.None[core.Result[T]](),
+	}
+}
+
+// Is adds a branch that handles errors matching target with errors.Is.
+// It is shorthand for Case(CaseIs(target, fn)).
+func (m ErrMatcher[T]) Is(target error, fn func(err error) core.Result[T]) ErrMatcher[T] {
+	return m.Case(CaseIs(target, fn))
+}
+
+// Case adds a branch built with CaseAs or CaseIs.
+func (m ErrMatcher[T]) Case(branch ErrCase[T]) ErrMatcher[T] {
+	if m.result.IsOk() || m.handled.IsSome() {
+		return m
+	}
+	m.handled = branch(m.result.UnwrapErr())
+	return m
+}
+
+// Otherwise returns the Result of the branch that handled the error.
+// If no branch did, fn is called with the error. An Ok result is returned unchanged.
+func (m ErrMatcher[T]) Otherwise(fn func(err error) core.Result[T]) core.Result[T] {
+	if m.result.IsOk() {
+		return m.result
+	}
+	return m.handled.UnwrapOrElse(func() core.Result[T] {
+		return fn(m.result.UnwrapErr())
+	})
+}
+
+// Result returns the Result of the branch that handled the error.
+// If no branch did, or the result is Ok, the original Result is returned.
+func (m ErrMatcher[T]) Result() core.Result[T] {
+	return m.handled.UnwrapOrElse(func() core.Result[T] {
+		return m.result
+	})
+}
+
+// Unhandled returns Some with the error if the result is Err and no branch
+// handled it, otherwise None. It lets tests assert that a matcher covers
+// every error a function can return.
+//
+// Example:
+//
+//	for _, err := range []error{ErrNotFound, &ValidationError{}} {
+//	    unhandled := route(Err[User](err)).Unhandled()
+//	    must.True(t, unhandled.IsNone())
+//	}
+func (m ErrMatcher[T]) Unhandled() core.Option[error] {
+	if m.result.IsOk() || m.handled.IsSome() {
+		return internal.None[error]()
+	}
+	return internal.Some(m.result.UnwrapErr())
+}
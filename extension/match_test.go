@@ -0,0 +1,168 @@
+package extension_test
+
+import (
+	"errors"
+	"io/fs"
+	"testing"
+
+	"github.com/shoenig/test/must"
+
+	"codeberg.org/yaadata/opt/core"
+	"codeberg.org/yaadata/opt/extension"
+	"codeberg.org/yaadata/opt/internal"
+)
+
+func TestMatchOption(t *testing.T) {
+	t.Parallel()
+	t.Run("Some calls onSome", func(t *testing.T) {
+		t.Parallel()
+		// [A]rrange
+		opt := internal.Some("Alice")
+		// [A]ct
+		actual := extension.MatchOption(opt,
+			func(name string) int { return len(name) },
+			func() int { return -1 },
+		)
+		// [A]ssert
+		must.Eq(t, 5, actual)
+	})
+
+	t.Run("None calls onNone", func(t *testing.T) {
+		t.Parallel()
+		// [A]rrange
+		opt := internal.None[string]()
+		// [A]ct
+		actual := extension.MatchOption(opt,
+			func(name string) int { return len(name) },
+			func() int { return -1 },
+		)
+		// [A]ssert
+		must.Eq(t, -1, actual)
+	})
+}
+
+func TestMatchResult(t *testing.T) {
+	t.Parallel()
+	t.Run("Ok calls onOk", func(t *testing.T) {
+		t.Parallel()
+		// [A]rrange
+		result := internal.Ok(3)
+		// [A]ct
+		actual := extension.MatchResult(result,
+			func(value int) string { return "ok" },
+			func(err error) string { return err.Error() },
+		)
+		// [A]ssert
+		must.Eq(t, "ok", actual)
+	})
+
+	t.Run("Err calls onErr", func(t *testing.T) {
+		t.Parallel()
+		// [A]rrange
+		result := internal.Err[int](errors.New("error"))
+		// [A]ct
+		actual := extension.MatchResult(result,
+			func(value int) string { return "ok" },
+			func(err error) string { return err.Error() },
+		)
+		// [A]ssert
+		must.Eq(t, "error", actual)
+	})
+}
+
+func TestMatchErr(t *testing.T) {
+	t.Parallel()
+	errNotFound := errors.New("not found")
+	route := func(result core.Result[string]) extension.ErrMatcher[string] {
+		return extension.MatchErr(result).
+			Is(errNotFound, func(err error) core.Result[string] {
+				return internal.Ok("guest")
+			}).
+			Case(extension.CaseAs(func(err *fs.PathError) core.Result[string] {
+				return internal.Ok(err.Path)
+			}))
+	}
+
+	t.Run("Is branch handles sentinel", func(t *testing.T) {
+		t.Parallel()
+		// [A]rrange
+		result := internal.Err[string](errNotFound)
+		// [A]ct
+		actual := route(result).Result()
+		// [A]ssert
+		must.Eq(t, "guest", actual.Unwrap())
+	})
+
+	t.Run("Case with CaseAs handles concrete type", func(t *testing.T) {
+		t.Parallel()
+		// [A]rrange
+		result := internal.Err[string](&fs.PathError{Op: "open", Path: "config.yaml", Err: fs.ErrNotExist})
+		// [A]ct
+		actual := route(result).Result()
+		// [A]ssert
+		must.Eq(t, "config.yaml", actual.Unwrap())
+	})
+
+	t.Run("First matching branch wins", func(t *testing.T) {
+		t.Parallel()
+		// [A]rrange
+		result := internal.Err[string](errNotFound)
+		// [A]ct
+		actual := route(result).
+			Is(errNotFound, func(err error) core.Result[string] {
+				return internal.Ok("second")
+			}).
+			Result()
+		// [A]ssert
+		must.Eq(t, "guest", actual.Unwrap())
+	})
+
+	t.Run("Otherwise handles unmatched Err", func(t *testing.T) {
+		t.Parallel()
+		// [A]rrange
+		result := internal.Err[string](errors.New("boom"))
+		// [A]ct
+		actual := route(result).Otherwise(func(err error) core.Result[string] {
+			return internal.Ok("fallback")
+		})
+		// [A]ssert
+		must.Eq(t, "fallback", actual.Unwrap())
+	})
+
+	t.Run("Unhandled reports unmatched Err", func(t *testing.T) {
+		t.Parallel()
+		// [A]rrange
+		expected := errors.New("boom")
+		result := internal.Err[string](expected)
+		// [A]ct
+		actual := route(result)
+		// [A]ssert
+		must.Eq(t, expected, actual.Unhandled().Unwrap())
+		must.Eq(t, expected, actual.Result().UnwrapErr())
+	})
+
+	t.Run("Unhandled is None when every error is covered", func(t *testing.T) {
+		t.Parallel()
+		// [A]rrange
+		errs := []error{errNotFound, &fs.PathError{Op: "open", Path: "a", Err: fs.ErrNotExist}}
+		for _, err := range errs {
+			// [A]ct
+			actual := route(internal.Err[string](err))
+			// [A]ssert
+			must.True(t, actual.Unhandled().IsNone())
+		}
+	})
+
+	t.Run("Ok skips every branch", func(t *testing.T) {
+		t.Parallel()
+		// [A]rrange
+		result := internal.Ok("value")
+		// [A]ct
+		actual := route(result).Otherwise(func(err error) core.Result[string] {
+			return internal.Ok("fallback")
+		})
+		// [A]ssert
+		must.Eq(t, "value", actual.Unwrap())
+		must.True(t, route(result).Unhandled().IsNone())
+	})
+}
@@ -22,6 +22,98 @@ func OptionFromPointer[T any](ptr *T) core.Option[T] {
 	return internal.OptionFromPointer(ptr)
 }
 
+// OptionFromMap looks up key in m.
+// Returns Some(value) if the key is present, otherwise None.
+//
+// Example:
+//
+//	ports := map[string]int{"http": 80}
+//	OptionFromMap(ports, "http")  // Some(80)
+//	OptionFromMap(ports, "https") // None
+func OptionFromMap[M ~map[K]V, K comparable, V any](m M, key K) core.Option[V] {
+	value, ok := m[key]
+	return OptionFromCommaOk(value, ok)
+}
+
+// OptionFromIndex returns Some(s[i]) if i is a valid index of s, otherwise None.
+//
+// Example:
+//
+//	args := []string{"serve", "--port"}
+//	OptionFromIndex(args, 0) // Some("serve")
+//	OptionFromIndex(args, 5) // None
+func OptionFromIndex[S ~[]T, T any](s S, i int) core.Option[T] {
+	if i < 0 || i >= len(s) {
+		return internal.None[T]()
+	}
+	return internal.Some(s[i])
+}
+
+// OptionFromCommaOk converts Go's comma-ok pattern into an Option.
+// Returns Some(value) if ok is true, otherwise None.
+//
+// Example:
+//
+//	opt := OptionFromCommaOk(os.LookupEnv("PORT")) // None if PORT is unset
+func OptionFromCommaOk[T any](value T, ok bool) core.Option[T] {
+	if !ok {
+		return internal.None[T]()
+	}
+	return internal.Some(value)
+}
+
+// OptionFromTypeAssert returns Some if value holds a T, otherwise None.
+// It is the Option form of CastOrZero.
+//
+// Example:
+//
+//	var value any = 42
+//	OptionFromTypeAssert[int](value)    // Some(42)
+//	OptionFromTypeAssert[string](value) // None
+func OptionFromTypeAssert[T any](value any) core.Option[T] {
+	typed, ok := value.(T)
+	return OptionFromCommaOk(typed, ok)
+}
+
+// OptionFromNonZero returns None if value is the zero value of T, otherwise Some(value).
+//
+// Example:
+//
+//	OptionFromNonZero(cfg.Timeout) // None if the timeout was not set
+//	OptionFromNonZero("")          // None
+//	OptionFromNonZero("value")     // Some("value")
+func OptionFromNonZero[T comparable](value T) core.Option[T] {
+	return OptionFromCommaOk(value, value != *new(T))
+}
+
+// OptionFromRecv receives from ch without blocking.
+// Returns Some(value) if a value was ready, or None if none was ready or ch is closed.
+//
+// Example:
+//
+//	if job := OptionFromRecv(jobs); job.IsSome() {
+//	    run(job.Unwrap())
+//	}
+func OptionFromRecv[T any](ch <-chan T) core.Option[T] {
+	select {
+	case value, ok := <-ch:
+		return OptionFromCommaOk(value, ok)
+	default:
+		return internal.None[T]()
+	}
+}
+
+// OptionFromErr returns None if err is nil, otherwise Some(err).
+//
+// Example:
+//
+//	OptionFromErr(file.Close()).Inspect(func(err error) {
+//	    log.Printf("close failed: %v", err)
+//	})
+func OptionFromErr(err error) core.Option[error] {
+	return OptionFromCommaOk(err, err != nil)
+}
+
 // OptionFlatten removes one level of nesting from a nested Option.
 // It converts Option[Option[T]] into Option[T].
 //
@@ -183,7 +275,7 @@ func OptionTranspose[T any](option core.Option[core.Result[T]]) core.Result[core
 	}
 	result := option.Unwrap()
 	if result.IsError() {
-		return internal.Err[core.Option[T]](result.UnwrapErr())
+		return internal.ErrFrom[core.Option[T]](result)
 	}
 	return internal.Ok(internal.Some(result.Unwrap()))
 }
@@ -0,0 +1,60 @@
+package extension
+
+import (
+	"codeberg.org/yaadata/opt/core"
+	"codeberg.org/yaadata/opt/internal"
+)
+
+// OptionResultFromResult converts Result[Option[T]] into an OptionResult.
+// Ok(Some(value)) becomes Some, Ok(None) becomes None and Err stays Err.
+//
+// Example:
+//
+//	user := OptionResultFromResult(repo.FindUser(id)) // repo returns Result[Option[User]]
+//	user.OrNotFound(ErrNotFound)
+func OptionResultFromResult[T any](result core.Result[core.Option[T]]) core.OptionResult[T] {
+	return internal.OptionResultFromResult(result)
+}
+
+// OptionResultFromOption converts Option[Result[T]] into an OptionResult.
+// None becomes None, Some(Ok(value)) becomes Some and Some(Err) becomes Err.
+//
+// Example:
+//
+//	cached := OptionResultFromOption(cache.Get(id)) // cache returns Option[Result[User]]
+func OptionResultFromOption[T any](option core.Option[core.Result[T]]) core.OptionResult[T] {
+	return internal.OptionResultFromResult(OptionTranspose(option))
+}
+
+// OptionResultMap transforms a found value with fn. None and Err are
+// returned unchanged.
+//
+// Example:
+//
+//	name := OptionResultMap(repo.FindUser(id), func(u User) string { return u.Name })
+func OptionResultMap[T, V any](option core.OptionResult[T], fn func(value T) V) core.OptionResult[V] {
+	return internal.OptionResultFromResult(ResultMap(option.Result(), func(inner core.Option[T]) core.Option[V] {
+		return OptionMap(inner, fn)
+	}))
+}
+
+// OptionResultAndThen calls fn with a found value and returns its
+// OptionResult. None and Err are returned unchanged without calling fn.
+//
+// Example:
+//
+//	org := OptionResultAndThen(repo.FindUser(id), func(u User) core.OptionResult[Org] {
+//	    return repo.FindOrg(u.OrgID)
+//	})
+func OptionResultAndThen[T, V any](
+	option core.OptionResult[T],
+	fn func(value T) core.OptionResult[V],
+) core.OptionResult[V] {
+	if option.IsSome() {
+		return fn(option.UnwrapOrDefault())
+	}
+	if option.IsNone() {
+		return internal.Absent[V]()
+	}
+	return internal.OptionResultFromResult(internal.ErrFrom[core.Option[V]](option.Result()))
+}
@@ -0,0 +1,74 @@
+package extension_test
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/shoenig/test/must"
+
+	"codeberg.org/yaadata/opt/core"
+	"codeberg.org/yaadata/opt/extension"
+	"codeberg.org/yaadata/opt/internal"
+)
+
+func TestOptionResultMap(t *testing.T) {
+	t.Parallel()
+	t.Run("Found is transformed", func(t *testing.T) {
+		t.Parallel()
+		// [A]ct
+		actual := extension.OptionResultMap(internal.Found(3), strconv.Itoa)
+		// [A]ssert
+		must.Eq(t, "3", actual.UnwrapOrDefault())
+	})
+
+	t.Run("Absent stays absent", func(t *testing.T) {
+		t.Parallel()
+		// [A]ct
+		actual := extension.OptionResultMap(internal.Absent[int](), strconv.Itoa)
+		// [A]ssert
+		must.True(t, actual.IsNone())
+	})
+
+	t.Run("Failed keeps the error", func(t *testing.T) {
+		t.Parallel()
+		// [A]rrange
+		cause := errors.New("connection refused")
+		// [A]ct
+		actual := extension.OptionResultMap(internal.Failed[int](cause), strconv.Itoa)
+		// [A]ssert
+		must.Eq(t, cause, actual.Err().Unwrap())
+	})
+}
+
+func TestOptionResultAndThen(t *testing.T) {
+	t.Parallel()
+	lookup := func(value int) core.OptionResult[string] {
+		if value > 0 {
+			return internal.Found(strconv.Itoa(value))
+		}
+		return internal.Absent[string]()
+	}
+
+	t.Run("Found chains into the next lookup", func(t *testing.T) {
+		t.Parallel()
+		// [A]ct
+		found := extension.OptionResultAndThen(internal.Found(3), lookup)
+		absent := extension.OptionResultAndThen(internal.Found(-3), lookup)
+		// [A]ssert
+		must.Eq(t, "3", found.UnwrapOrDefault())
+		must.True(t, absent.IsNone())
+	})
+
+	t.Run("Absent and Failed skip the lookup", func(t *testing.T) {
+		t.Parallel()
+		// [A]rrange
+		cause := errors.New("connection refused")
+		// [A]ct
+		absent := extension.OptionResultAndThen(internal.Absent[int](), lookup)
+		failed := extension.OptionResultAndThen(internal.Failed[int](cause), lookup)
+		// [A]ssert
+		must.True(t, absent.IsNone())
+		must.Eq(t, cause, failed.Err().Unwrap())
+	})
+}
@@ -241,3 +241,168 @@ func TestOptionFromPointer(t *testing.T) {
 		must.Eq(t, value, actual.Unwrap())
 	})
 }
+
+func TestOptionFromMap(t *testing.T) {
+	t.Parallel()
+	ports := map[string]int{"http": 80, "zero": 0}
+	t.Run("Present key returns Some", func(t *testing.T) {
+		t.Parallel()
+		// [A]ct
+		actual := extension.OptionFromMap(ports, "http")
+		zero := extension.OptionFromMap(ports, "zero")
+		// [A]ssert
+		must.Eq(t, 80, actual.Unwrap())
+		must.Eq(t, 0, zero.Unwrap())
+	})
+
+	t.Run("Missing key returns None", func(t *testing.T) {
+		t.Parallel()
+		// [A]ct
+		actual := extension.OptionFromMap(ports, "https")
+		// [A]ssert
+		must.True(t, actual.IsNone())
+	})
+}
+
+func TestOptionFromIndex(t *testing.T) {
+	t.Parallel()
+	args := []string{"serve", "--port"}
+	t.Run("Valid index returns Some", func(t *testing.T) {
+		t.Parallel()
+		// [A]ct
+		actual := extension.OptionFromIndex(args, 1)
+		// [A]ssert
+		must.Eq(t, "--port", actual.Unwrap())
+	})
+
+	t.Run("Out of range index returns None", func(t *testing.T) {
+		t.Parallel()
+		// [A]ct
+		past := extension.OptionFromIndex(args, 2)
+		negative := extension.OptionFromIndex(args, -1)
+		// [A]ssert
+		must.True(t, past.IsNone())
+		must.True(t, negative.IsNone())
+	})
+}
+
+func TestOptionFromCommaOk(t *testing.T) {
+	t.Parallel()
+	t.Run("Ok returns Some", func(t *testing.T) {
+		t.Parallel()
+		// [A]ct
+		actual := extension.OptionFromCommaOk("value", true)
+		// [A]ssert
+		must.Eq(t, "value", actual.Unwrap())
+	})
+
+	t.Run("Not ok returns None", func(t *testing.T) {
+		t.Parallel()
+		// [A]ct
+		actual := extension.OptionFromCommaOk("value", false)
+		// [A]ssert
+		must.True(t, actual.IsNone())
+	})
+}
+
+func TestOptionFromTypeAssert(t *testing.T) {
+	t.Parallel()
+	t.Run("Matching type returns Some", func(t *testing.T) {
+		t.Parallel()
+		// [A]rrange
+		var value any = 42
+		// [A]ct
+		actual := extension.OptionFromTypeAssert[int](value)
+		// [A]ssert
+		must.Eq(t, 42, actual.Unwrap())
+	})
+
+	t.Run("Other type returns None", func(t *testing.T) {
+		t.Parallel()
+		// [A]rrange
+		var value any = 42
+		// [A]ct
+		actual := extension.OptionFromTypeAssert[string](value)
+		nilValue := extension.OptionFromTypeAssert[error](nil)
+		// [A]ssert
+		must.True(t, actual.IsNone())
+		must.True(t, nilValue.IsNone())
+	})
+}
+
+func TestOptionFromNonZero(t *testing.T) {
+	t.Parallel()
+	t.Run("Non-zero value returns Some", func(t *testing.T) {
+		t.Parallel()
+		// [A]ct
+		actual := extension.OptionFromNonZero("value")
+		// [A]ssert
+		must.Eq(t, "value", actual.Unwrap())
+	})
+
+	t.Run("Zero value returns None", func(t *testing.T) {
+		t.Parallel()
+		// [A]ct
+		str := extension.OptionFromNonZero("")
+		num := extension.OptionFromNonZero(0)
+		// [A]ssert
+		must.True(t, str.IsNone())
+		must.True(t, num.IsNone())
+	})
+}
+
+func TestOptionFromRecv(t *testing.T) {
+	t.Parallel()
+	t.Run("Ready value returns Some", func(t *testing.T) {
+		t.Parallel()
+		// [A]rrange
+		ch := make(chan int, 1)
+		ch <- 3
+		// [A]ct
+		actual := extension.OptionFromRecv(ch)
+		// [A]ssert
+		must.Eq(t, 3, actual.Unwrap())
+	})
+
+	t.Run("Empty channel returns None without blocking", func(t *testing.T) {
+		t.Parallel()
+		// [A]rrange
+		ch := make(chan int)
+		// [A]ct
+		actual := extension.OptionFromRecv(ch)
+		// [A]ssert
+		must.True(t, actual.IsNone())
+	})
+
+	t.Run("Closed channel returns None", func(t *testing.T) {
+		t.Parallel()
+		// [A]rrange
+		ch := make(chan int)
+		close(ch)
+		// [A]ct
+		actual := extension.OptionFromRecv(ch)
+		// [A]ssert
+		must.True(t, actual.IsNone())
+	})
+}
+
+func TestOptionFromErr(t *testing.T) {
+	t.Parallel()
+	t.Run("Nil returns None", func(t *testing.T) {
+		t.Parallel()
+		// [A]ct
+		actual := extension.OptionFromErr(nil)
+		// [A]ssert
+		must.True(t, actual.IsNone())
+	})
+
+	t.Run("Error returns Some", func(t *testing.T) {
+		t.Parallel()
+		// [A]rrange
+		cause := errors.New("close failed")
+		// [A]ct
+		actual := extension.OptionFromErr(cause)
+		// [A]ssert
+		must.Eq(t, cause, actual.Unwrap())
+	})
+}
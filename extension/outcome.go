@@ -0,0 +1,42 @@
+package extension
+
+import (
+	"codeberg.org/yaadata/opt/core"
+	"codeberg.org/yaadata/opt/internal"
+)
+
+// OutcomeFromError converts a plain error return into an Outcome.
+// A nil err becomes Success, anything else becomes Failure.
+//
+// Example:
+//
+//	outcome := OutcomeFromError(os.Remove(path))
+//	outcome.InspectErr(func(err error) { log.Print(err) })
+func OutcomeFromError(err error) core.Outcome {
+	return internal.OutcomeFromError(err)
+}
+
+// OutcomeFromResult drops the Ok value of result. Ok becomes Success and
+// Err becomes Failure with the same error.
+//
+// Example:
+//
+//	outcome := OutcomeFromResult(db.Exec(query)) // the row count is not needed
+func OutcomeFromResult[T any](result core.Result[T]) core.Outcome {
+	return internal.OutcomeFromResult(result)
+}
+
+// OutcomeAndThen calls fn if outcome is Success and returns its Result.
+// If outcome is Failure, fn is not called and Err with the same error is returned.
+//
+// Example:
+//
+//	user := OutcomeAndThen(store.Put(u), func() core.Result[User] {
+//	    return store.Get(u.ID)
+//	})
+func OutcomeAndThen[T any](outcome core.Outcome, fn func() core.Result[T]) core.Result[T] {
+	if outcome.IsSuccess() {
+		return fn()
+	}
+	return internal.ErrFromOutcome[T](outcome)
+}
@@ -0,0 +1,82 @@
+package extension_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/shoenig/test/must"
+
+	"codeberg.org/yaadata/opt/core"
+	"codeberg.org/yaadata/opt/extension"
+	"codeberg.org/yaadata/opt/internal"
+)
+
+func TestOutcomeFromError(t *testing.T) {
+	t.Parallel()
+	t.Run("Nil is Success", func(t *testing.T) {
+		t.Parallel()
+		// [A]ct
+		actual := extension.OutcomeFromError(nil)
+		// [A]ssert
+		must.True(t, actual.IsSuccess())
+	})
+
+	t.Run("Error is Failure", func(t *testing.T) {
+		t.Parallel()
+		// [A]rrange
+		cause := errors.New("disk full")
+		// [A]ct
+		actual := extension.OutcomeFromError(cause)
+		// [A]ssert
+		must.Eq(t, cause, actual.Error())
+	})
+}
+
+func TestOutcomeFromResult(t *testing.T) {
+	t.Parallel()
+	t.Run("Ok is Success", func(t *testing.T) {
+		t.Parallel()
+		// [A]ct
+		actual := extension.OutcomeFromResult(internal.Ok(3))
+		// [A]ssert
+		must.True(t, actual.IsSuccess())
+	})
+
+	t.Run("Err is Failure", func(t *testing.T) {
+		t.Parallel()
+		// [A]rrange
+		cause := errors.New("disk full")
+		// [A]ct
+		actual := extension.OutcomeFromResult(internal.Err[int](cause))
+		// [A]ssert
+		must.Eq(t, cause, actual.Error())
+	})
+}
+
+func TestOutcomeAndThen(t *testing.T) {
+	t.Parallel()
+	t.Run("Success calls fn", func(t *testing.T) {
+		t.Parallel()
+		// [A]ct
+		actual := extension.OutcomeAndThen(internal.Success(), func() core.Result[int] {
+			return internal.Ok(3)
+		})
+		// [A]ssert
+		must.Eq(t, 3, actual.Unwrap())
+	})
+
+	t.Run("Failure skips fn", func(t *testing.T) {
+		t.Parallel()
+		// [A]rrange
+		cause := errors.New("disk full")
+		called := false
+		// [A]ct
+		actual := extension.OutcomeAndThen(internal.OutcomeFromError(cause), func() core.Result[int] {
+			called = true
+			return internal.Ok(3)
+		})
+		// [A]ssert
+		must.False(t, called)
+		must.Eq(t, cause, actual.UnwrapErr())
+	})
+}
@@ -0,0 +1,73 @@
+package extension
+
+import (
+	"fmt"
+	"runtime/debug"
+
+	"codeberg.org/yaadata/opt/core"
+	"codeberg.org/yaadata/opt/internal"
+)
+
+// PanicError is the error a recovered panic is converted into.
+type PanicError struct {
+	// Value is the value passed to panic.
+	Value any
+	// Stack is the stack trace of the panicking goroutine at the time of the panic.
+	Stack []byte
+}
+
+// newPanicError must be called from the deferred function that recovered
+// value so that the captured stack still includes the panicking frames.
+func newPanicError(value any) *PanicError {
+	return &PanicError{Value: value, Stack: debug.Stack()}
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("panic: %v", e.Value)
+}
+
+// Unwrap returns the panic value if it is an error, allowing errors.Is and
+// errors.As to inspect it.
+func (e *PanicError) Unwrap() error {
+	if err, ok := e.Value.(error); ok {
+		return err
+	}
+	return nil
+}
+
+// Catch calls fn and returns its value as Ok.
+// If fn panics, the panic is recovered and returned as Err(*PanicError).
+//
+// Panics raised by Unwrap, Expect, UnwrapErr and ExpectErr unwrap to
+// core.ErrUnwrapNone, core.ErrUnwrapErr or core.ErrUnwrapOk, so they can be
+// told apart from other panics with errors.Is.
+//
+// Example:
+//
+//	result := Catch(func() int {
+//	    return config.Port().Unwrap()
+//	})
+//	errors.Is(result.UnwrapErr(), core.ErrUnwrapNone) // true if Port() was None
+func Catch[T any](fn func() T) core.Result[T] {
+	return CatchResult(func() core.Result[T] {
+		return internal.Ok(fn())
+	})
+}
+
+// CatchResult calls fn and returns its Result.
+// If fn panics, the panic is recovered and returned as Err(*PanicError),
+// classified the same way as Catch.
+//
+// Example:
+//
+//	result := CatchResult(func() core.Result[User] {
+//	    return lookup(id).OkOr(ErrNotFound)
+//	})
+func CatchResult[T any](fn func() core.Result[T]) (result core.Result[T]) {
+	defer func() {
+		if value := recover(); value != nil {
+			result = internal.Err[T](newPanicError(value))
+		}
+	}()
+	return fn()
+}
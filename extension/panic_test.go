@@ -0,0 +1,105 @@
+package extension_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/shoenig/test/must"
+
+	"codeberg.org/yaadata/opt/core"
+	"codeberg.org/yaadata/opt/extension"
+	"codeberg.org/yaadata/opt/internal"
+)
+
+func TestCatch(t *testing.T) {
+	t.Parallel()
+	t.Run("Value is returned as Ok", func(t *testing.T) {
+		t.Parallel()
+		// [A]ct
+		actual := extension.Catch(func() int { return 42 })
+		// [A]ssert
+		must.Eq(t, 42, actual.Unwrap())
+	})
+
+	t.Run("Panic is returned as PanicError", func(t *testing.T) {
+		t.Parallel()
+		// [A]ct
+		actual := extension.Catch(func() int { panic("boom") })
+		// [A]ssert
+		var panicErr *extension.PanicError
+		must.True(t, errors.As(actual.UnwrapErr(), &panicErr))
+		must.Eq(t, any("boom"), panicErr.Value)
+		must.Nil(t, panicErr.Unwrap())
+		must.EqError(t, panicErr, "panic: boom")
+		must.StrContains(t, string(panicErr.Stack), "panic_test.go")
+	})
+
+	t.Run("Panic with an error unwraps to it", func(t *testing.T) {
+		t.Parallel()
+		// [A]rrange
+		cause := errors.New("cause")
+		// [A]ct
+		actual := extension.Catch(func() int { panic(cause) })
+		// [A]ssert
+		must.ErrorIs(t, actual.UnwrapErr(), cause)
+	})
+
+	t.Run("Option Unwrap on None is classified", func(t *testing.T) {
+		t.Parallel()
+		// [A]ct
+		actual := extension.Catch(func() int { return internal.None[int]().Unwrap() })
+		// [A]ssert
+		must.ErrorIs(t, actual.UnwrapErr(), core.ErrUnwrapNone)
+		must.False(t, errors.Is(actual.UnwrapErr(), core.ErrUnwrapErr))
+	})
+
+	t.Run("Option Expect on None is classified", func(t *testing.T) {
+		t.Parallel()
+		// [A]ct
+		actual := extension.Catch(func() int { return internal.None[int]().Expect("port required") })
+		// [A]ssert
+		must.ErrorIs(t, actual.UnwrapErr(), core.ErrUnwrapNone)
+		must.StrContains(t, actual.UnwrapErr().Error(), "port required")
+	})
+
+	t.Run("Result Unwrap on Err is classified", func(t *testing.T) {
+		t.Parallel()
+		// [A]ct
+		actual := extension.Catch(func() int { return internal.Err[int](errors.New("e")).Unwrap() })
+		// [A]ssert
+		must.ErrorIs(t, actual.UnwrapErr(), core.ErrUnwrapErr)
+	})
+
+	t.Run("Result UnwrapErr on Ok is classified", func(t *testing.T) {
+		t.Parallel()
+		// [A]ct
+		actual := extension.Catch(func() error { return internal.Ok(1).UnwrapErr() })
+		// [A]ssert
+		must.ErrorIs(t, actual.UnwrapErr(), core.ErrUnwrapOk)
+	})
+}
+
+func TestCatchResult(t *testing.T) {
+	t.Parallel()
+	t.Run("Result is returned unchanged", func(t *testing.T) {
+		t.Parallel()
+		// [A]rrange
+		expected := errors.New("expected")
+		// [A]ct
+		actual := extension.CatchResult(func() core.Result[int] { return internal.Err[int](expected) })
+		// [A]ssert
+		must.Eq(t, expected, actual.UnwrapErr())
+	})
+
+	t.Run("Panic is returned as PanicError", func(t *testing.T) {
+		t.Parallel()
+		// [A]ct
+		actual := extension.CatchResult(func() core.Result[int] {
+			return internal.Ok(internal.None[int]().Unwrap())
+		})
+		// [A]ssert
+		var panicErr *extension.PanicError
+		must.True(t, errors.As(actual.UnwrapErr(), &panicErr))
+		must.ErrorIs(t, panicErr, core.ErrUnwrapNone)
+	})
+}
@@ -0,0 +1,236 @@
+package extension
+
+import (
+	"codeberg.org/yaadata/opt/core"
+	"codeberg.org/yaadata/opt/internal"
+)
+
+// OptionStep lifts a plain function into a step for the OptionPipe functions.
+//
+// Example:
+//
+//	OptionPipe2(Some("42"), parseInt, OptionStep(strconv.Itoa))
+func OptionStep[A, B any](fn func(A) B) func(A) core.Option[B] {
+	return func(value A) core.Option[B] {
+		return internal.Some(fn(value))
+	}
+}
+
+// OptionStepFromResult turns a Result-returning function into a step for the
+// OptionPipe functions. An Err becomes None and its error is discarded.
+//
+// Example:
+//
+//	OptionPipe2(lookupUser(id), userName, OptionStepFromResult(loadAvatar))
+func OptionStepFromResult[A, B any](fn func(A) core.Result[B]) func(A) core.Option[B] {
+	return func(value A) core.Option[B] {
+		return fn(value).Ok()
+	}
+}
+
+// ResultStep lifts a plain function into a step for the ResultPipe functions.
+//
+// Example:
+//
+//	ResultPipe2(Ok("42"), parseInt, ResultStep(double))
+func ResultStep[A, B any](fn func(A) B) func(A) core.Result[B] {
+	return func(value A) core.Result[B] {
+		return internal.Ok(fn(value))
+	}
+}
+
+// ResultStepFromOption turns an Option-returning function into a step for the
+// ResultPipe functions. None becomes Err(err).
+//
+// Example:
+//
+//	ResultPipe2(fetchUser(id), ResultStepFromOption(primaryEmail, ErrNoEmail), sendWelcome)
+func ResultStepFromOption[A, B any](fn func(A) core.Option[B], err error) func(A) core.Result[B] {
+	return func(value A) core.Result[B] {
+		return fn(value).OkOr(err)
+	}
+}
+
+// OptionPipe2 passes the value of opt through two steps, keeping the static
+// type of every intermediate value. The first step that returns None stops
+// the pipeline and None is returned. Plain functions and Result-returning
+// functions are adapted with OptionStep and OptionStepFromResult.
+//
+// OptionPipe3 to OptionPipe8 do the same with more steps.
+//
+// Example:
+//
+//	port := OptionPipe2(lookupEnv("PORT"),
+//	    OptionStepFromResult(parsePort),
+//	    OptionStep(strconv.Itoa),
+//	) // Some("8080"), or None if PORT is unset or invalid
+func OptionPipe2[A, B, C any](opt core.Option[A], f1 func(A) core.Option[B], f2 func(B) core.Option[C]) core.Option[C] {
+	return OptionAndThen(OptionAndThen(opt, f1), f2)
+}
+
+// OptionPipe3 is OptionPipe2 with three steps.
+func OptionPipe3[A, B, C, D any](
+	opt core.Option[A],
+	f1 func(A) core.Option[B],
+	f2 func(B) core.Option[C],
+	f3 func(C) core.Option[D],
+) core.Option[D] {
+	return OptionAndThen(OptionPipe2(opt, f1, f2), f3)
+}
+
+// OptionPipe4 is OptionPipe2 with four steps.
+func OptionPipe4[A, B, C, D, E any](
+	opt core.Option[A],
+	f1 func(A) core.Option[B],
+	f2 func(B) core.Option[C],
+	f3 func(C) core.Option[D],
+	f4 func(D) core.Option[E],
+) core.Option[E] {
+	return OptionAndThen(OptionPipe3(opt, f1, f2, f3), f4)
+}
+
+// OptionPipe5 is OptionPipe2 with five steps.
+func OptionPipe5[A, B, C, D, E, F any](
+	opt core.Option[A],
+	f1 func(A) core.Option[B],
+	f2 func(B) core.Option[C],
+	f3 func(C) core.Option[D],
+	f4 func(D) core.Option[E],
+	f5 func(E) core.Option[F],
+) core.Option[F] {
+	return OptionAndThen(OptionPipe4(opt, f1, f2, f3, f4), f5)
+}
+
+// OptionPipe6 is OptionPipe2 with six steps.
+func OptionPipe6[A, B, C, D, E, F, G any](
+	opt core.Option[A],
+	f1 func(A) core.Option[B],
+	f2 func(B) core.Option[C],
+	f3 func(C) core.Option[D],
+	f4 func(D) core.Option[E],
+	f5 func(E) core.Option[F],
+	f6 func(F) core.Option[G],
+) core.Option[G] {
+	return OptionAndThen(OptionPipe5(opt, f1, f2, f3, f4, f5), f6)
+}
+
+// OptionPipe7 is OptionPipe2 with seven steps.
+func OptionPipe7[A, B, C, D, E, F, G, H any](
+	opt core.Option[A],
+	f1 func(A) core.Option[B],
+	f2 func(B) core.Option[C],
+	f3 func(C) core.Option[D],
+	f4 func(D) core.Option[E],
+	f5 func(E) core.Option[F],
+	f6 func(F) core.Option[G],
+	f7 func(G) core.Option[H],
+) core.Option[H] {
+	return OptionAndThen(OptionPipe6(opt, f1, f2, f3, f4, f5, f6), f7)
+}
+
+// OptionPipe8 is OptionPipe2 with eight steps.
+func OptionPipe8[A, B, C, D, E, F, G, H, I any](
+	opt core.Option[A],
+	f1 func(A) core.Option[B],
+	f2 func(B) core.Option[C],
+	f3 func(C) core.Option[D],
+	f4 func(D) core.Option[E],
+	f5 func(E) core.Option[F],
+	f6 func(F) core.Option[G],
+	f7 func(G) core.Option[H],
+	f8 func(H) core.Option[I],
+) core.Option[I] {
+	return OptionAndThen(OptionPipe7(opt, f1, f2, f3, f4, f5, f6, f7), f8)
+}
+
+// ResultPipe2 passes the value of res through two steps, keeping the static
+// type of every intermediate value. The first step that returns Err stops
+// the pipeline and that Err is returned. Plain functions and Option-returning
+// functions are adapted with ResultStep and ResultStepFromOption.
+//
+// ResultPipe3 to ResultPipe8 do the same with more steps.
+//
+// Example:
+//
+//	invoice := ResultPipe2(fetchOrder(id),
+//	    ResultStepFromOption(billingAddress, ErrNoAddress),
+//	    ResultStep(renderInvoice),
+//	) // Ok(Invoice), or the first Err
+func ResultPipe2[A, B, C any](res core.Result[A], f1 func(A) core.Result[B], f2 func(B) core.Result[C]) core.Result[C] {
+	return ResultAndThen(ResultAndThen(res, f1), f2)
+}
+
+// ResultPipe3 is ResultPipe2 with three steps.
+func ResultPipe3[A, B, C, D any](
+	res core.Result[A],
+	f1 func(A) core.Result[B],
+	f2 func(B) core.Result[C],
+	f3 func(C) core.Result[D],
+) core.Result[D] {
+	return ResultAndThen(ResultPipe2(res, f1, f2), f3)
+}
+
+// ResultPipe4 is ResultPipe2 with four steps.
+func ResultPipe4[A, B, C, D, E any](
+	res core.Result[A],
+	f1 func(A) core.Result[B],
+	f2 func(B) core.Result[C],
+	f3 func(C) core.Result[D],
+	f4 func(D) core.Result[E],
+) core.Result[E] {
+	return ResultAndThen(ResultPipe3(res, f1, f2, f3), f4)
+}
+
+// ResultPipe5 is ResultPipe2 with five steps.
+func ResultPipe5[A, B, C, D, E, F any](
+	res core.Result[A],
+	f1 func(A) core.Result[B],
+	f2 func(B) core.Result[C],
+	f3 func(C) core.Result[D],
+	f4 func(D) core.Result[E],
+	f5 func(E) core.Result[F],
+) core.Result[F] {
+	return ResultAndThen(ResultPipe4(res, f1, f2, f3, f4), f5)
+}
+
+// ResultPipe6 is ResultPipe2 with six steps.
+func ResultPipe6[A, B, C, D, E, F, G any](
+	res core.Result[A],
+	f1 func(A) core.Result[B],
+	f2 func(B) core.Result[C],
+	f3 func(C) core.Result[D],
+	f4 func(D) core.Result[E],
+	f5 func(E) core.Result[F],
+	f6 func(F) core.Result[G],
+) core.Result[G] {
+	return ResultAndThen(ResultPipe5(res, f1, f2, f3, f4, f5), f6)
+}
+
+// ResultPipe7 is ResultPipe2 with seven steps.
+func ResultPipe7[A, B, C, D, E, F, G, H any](
+	res core.Result[A],
+	f1 func(A) core.Result[B],
+	f2 func(B) core.Result[C],
+	f3 func(C) core.Result[D],
+	f4 func(D) core.Result[E],
+	f5 func(E) core.Result[F],
+	f6 func(F) core.Result[G],
+	f7 func(G) core.Result[H],
+) core.Result[H] {
+	return ResultAndThen(ResultPipe6(res, f1, f2, f3, f4, f5, f6), f7)
+}
+
+// ResultPipe8 is ResultPipe2 with eight steps.
+func ResultPipe8[A, B, C, D, E, F, G, H, I any](
+	res core.Result[A],
+	f1 func(A) core.Result[B],
+	f2 func(B) core.Result[C],
+	f3 func(C) core.Result[D],
+	f4 func(D) core.Result[E],
+	f5 func(E) core.Result[F],
+	f6 func(F) core.Result[G],
+	f7 func(G) core.Result[H],
+	f8 func(H) core.Result[I],
+) core.Result[I] {
+	return ResultAndThen(ResultPipe7(res, f1, f2, f3, f4, f5, f6, f7), f8)
+}
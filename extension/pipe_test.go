@@ -0,0 +1,139 @@
+package extension_test
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/shoenig/test/must"
+
+	"codeberg.org/yaadata/opt/core"
+	"codeberg.org/yaadata/opt/extension"
+	"codeberg.org/yaadata/opt/internal"
+)
+
+func parseInt(value string) core.Result[int] {
+	return internal.ResultFromReturn(strconv.Atoi(value))
+}
+
+func positive(value int) core.Option[int] {
+	if value > 0 {
+		return internal.Some(value)
+	}
+	return internal.None[int]()
+}
+
+func TestOptionPipe(t *testing.T) {
+	t.Parallel()
+	t.Run("Every step Some", func(t *testing.T) {
+		t.Parallel()
+		// [A]rrange
+		opt := internal.Some("3")
+		// [A]ct
+		actual := extension.OptionPipe3(opt,
+			extension.OptionStepFromResult(parseInt),
+			positive,
+			extension.OptionStep(func(value int) string { return strings.Repeat("A", value) }),
+		)
+		// [A]ssert
+		must.Eq(t, "AAA", actual.Unwrap())
+	})
+
+	t.Run("None stops the pipeline", func(t *testing.T) {
+		t.Parallel()
+		// [A]rrange
+		opt := internal.Some("-3")
+		called := false
+		// [A]ct
+		actual := extension.OptionPipe3(opt,
+			extension.OptionStepFromResult(parseInt),
+			positive,
+			extension.OptionStep(func(value int) string {
+				called = true
+				return strings.Repeat("A", value)
+			}),
+		)
+		// [A]ssert
+		must.True(t, actual.IsNone())
+		must.False(t, called)
+	})
+
+	t.Run("Err step becomes None", func(t *testing.T) {
+		t.Parallel()
+		// [A]rrange
+		opt := internal.Some("three")
+		// [A]ct
+		actual := extension.OptionPipe2(opt, extension.OptionStepFromResult(parseInt), positive)
+		// [A]ssert
+		must.True(t, actual.IsNone())
+	})
+
+	t.Run("Eight steps", func(t *testing.T) {
+		t.Parallel()
+		// [A]rrange
+		inc := extension.OptionStep(func(value int) int { return value + 1 })
+		// [A]ct
+		actual := extension.OptionPipe8(internal.Some(0), inc, inc, inc, inc, inc, inc, inc, positive)
+		// [A]ssert
+		must.Eq(t, 7, actual.Unwrap())
+	})
+}
+
+func TestResultPipe(t *testing.T) {
+	t.Parallel()
+	errNotPositive := errors.New("not positive")
+	t.Run("Every step Ok", func(t *testing.T) {
+		t.Parallel()
+		// [A]rrange
+		result := internal.Ok("3")
+		// [A]ct
+		actual := extension.ResultPipe3(result,
+			parseInt,
+			extension.ResultStepFromOption(positive, errNotPositive),
+			extension.ResultStep(func(value int) string { return strings.Repeat("A", value) }),
+		)
+		// [A]ssert
+		must.Eq(t, "AAA", actual.Unwrap())
+	})
+
+	t.Run("First Err stops the pipeline", func(t *testing.T) {
+		t.Parallel()
+		// [A]rrange
+		result := internal.Ok("-3")
+		called := false
+		// [A]ct
+		actual := extension.ResultPipe3(result,
+			parseInt,
+			extension.ResultStepFromOption(positive, errNotPositive),
+			extension.ResultStep(func(value int) string {
+				called = true
+				return strings.Repeat("A", value)
+			}),
+		)
+		// [A]ssert
+		must.ErrorIs(t, actual.UnwrapErr(), errNotPositive)
+		must.False(t, called)
+	})
+
+	t.Run("Err input is returned unchanged", func(t *testing.T) {
+		t.Parallel()
+		// [A]rrange
+		expected := errors.New("error")
+		result := internal.Err[string](expected)
+		// [A]ct
+		actual := extension.ResultPipe2(result, parseInt, extension.ResultStepFromOption(positive, errNotPositive))
+		// [A]ssert
+		must.Eq(t, expected, actual.UnwrapErr())
+	})
+
+	t.Run("Eight steps", func(t *testing.T) {
+		t.Parallel()
+		// [A]rrange
+		inc := extension.ResultStep(func(value int) int { return value + 1 })
+		// [A]ct
+		actual := extension.ResultPipe8(internal.Ok(0), inc, inc, inc, inc, inc, inc, inc, inc)
+		// [A]ssert
+		must.Eq(t, 8, actual.Unwrap())
+	})
+}
@@ -45,6 +45,34 @@ func ResultFromReturn[T any](value T, err error) core.Result[T] {
 	return internal.ResultFromReturn(value, err)
 }
 
+// ResultFromReturn2 converts Go's (a, b, error) return pattern into a Result
+// of a Pair. If err is not nil, returns Err. Otherwise, returns Ok with both values.
+//
+// Example:
+//
+//	result := ResultFromReturn2(net.SplitHostPort("db:5432"))
+//	host, port := result.Unwrap().Unpack() // "db", "5432"
+//
+//	result := ResultFromReturn2(net.SplitHostPort("db"))
+//	result.IsError() // true
+func ResultFromReturn2[A, B any](a A, b B, err error) core.Result[core.Pair[A, B]] {
+	return internal.ResultFromReturn(core.Pair[A, B]{First: a, Second: b}, err)
+}
+
+// ResultFromReturn3 converts Go's (a, b, c, error) return pattern into a
+// Result of a Triple. If err is not nil, returns Err. Otherwise, returns Ok
+// with all three values.
+//
+// Example:
+//
+//	func (r *Repo) Load(id int) (Doc, Version, time.Time, error)
+//
+//	result := ResultFromReturn3(repo.Load(id))
+//	doc, version, modified := result.Unwrap().Unpack()
+func ResultFromReturn3[A, B, C any](a A, b B, c C, err error) core.Result[core.Triple[A, B, C]] {
+	return internal.ResultFromReturn(core.Triple[A, B, C]{First: a, Second: b, Third: c}, err)
+}
+
 // ResultFlatten converts a nested Result[Result[T]] into a single-level Result[T].
 // If the outer result is Err, returns Err with that error.
 // If the outer result is Ok, returns the inner result.
@@ -67,7 +95,7 @@ func ResultFromReturn[T any](value T, err error) core.Result[T] {
 //	flattened.Unwrap() // 5
 func ResultFlatten[T any](result core.Result[core.Result[T]]) core.Result[T] {
 	if result.IsError() {
-		return internal.Err[T](result.UnwrapErr())
+		return internal.ErrFrom[T](result)
 	}
 	return result.Unwrap()
 }
@@ -89,7 +117,7 @@ func ResultAnd[T, V any](result core.Result[T], other core.Result[V]) core.Resul
 	if result.IsOk() {
 		return other
 	}
-	return internal.Err[V](result.UnwrapErr())
+	return internal.ErrFrom[V](result)
 }
 
 // ResultAndThen applies fn to the value inside result if it is Ok, otherwise returns the Err.
@@ -111,7 +139,7 @@ func ResultAndThen[T, V any](result core.Result[T], fn func(resultValue T) core.
 	if result.IsOk() {
 		return fn(result.Unwrap())
 	}
-	return internal.Err[V](result.UnwrapErr())
+	return internal.ErrFrom[V](result)
 }
 
 // ResultMap transforms a Result[T] to Result[V] by applying a function to the Ok value.
@@ -214,7 +242,7 @@ func ResultMapOrElse[T, V any](result core.Result[T], fn func(inner T) V, orElse
 //	transposed.Unwrap().UnwrapErr() // "msg"
 func ResultTranspose[T any](result core.Result[core.Option[T]]) core.Option[core.Result[T]] {
 	if result.IsError() {
-		return internal.Some(internal.Err[T](result.UnwrapErr()))
+		return internal.Some(internal.ErrFrom[T](result))
 	}
 	option := result.Unwrap()
 	if option.IsNone() {
@@ -2,6 +2,7 @@ package extension_test
 
 import (
 	"errors"
+	"net"
 	"strings"
 	"testing"
 
@@ -63,6 +64,53 @@ func TestResultMapFromReturn(t *testing.T) {
 	})
 }
 
+func TestResultFromReturn2(t *testing.T) {
+	t.Parallel()
+	t.Run("values with no error", func(t *testing.T) {
+		t.Parallel()
+		// [A]ct
+		actual := extension.ResultFromReturn2(net.SplitHostPort("db:5432"))
+		// [A]ssert
+		host, port := actual.Unwrap().Unpack()
+		must.Eq(t, "db", host)
+		must.Eq(t, "5432", port)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		t.Parallel()
+		// [A]ct
+		actual := extension.ResultFromReturn2(net.SplitHostPort("db"))
+		// [A]ssert
+		must.True(t, actual.IsError())
+	})
+}
+
+func TestResultFromReturn3(t *testing.T) {
+	t.Parallel()
+	load := func(fail bool) (string, int, bool, error) {
+		if fail {
+			return "", 0, false, errors.New("not found")
+		}
+		return "doc", 3, true, nil
+	}
+
+	t.Run("values with no error", func(t *testing.T) {
+		t.Parallel()
+		// [A]ct
+		actual := extension.ResultFromReturn3(load(false))
+		// [A]ssert
+		must.Eq(t, core.Triple[string, int, bool]{First: "doc", Second: 3, Third: true}, actual.Unwrap())
+	})
+
+	t.Run("error", func(t *testing.T) {
+		t.Parallel()
+		// [A]ct
+		actual := extension.ResultFromReturn3(load(true))
+		// [A]ssert
+		must.EqError(t, actual.UnwrapErr(), "not found")
+	})
+}
+
 func TestResultMap(t *testing.T) {
 	t.Parallel()
 	t.Run("Original result is Ok", func(t *testing.T) {
@@ -0,0 +1,23 @@
+package extension
+
+import "codeberg.org/yaadata/opt/internal"
+
+// SetTraceCapture turns on or off recording of the call stack when an Err
+// Result is created, and returns whether capture was on before.
+//
+// Capture is off by default; building with the opttrace tag turns it on from
+// the start. The recorded stack is available through Result.Trace and is
+// printed by formatting the Result with %+v. Results derived from an Err,
+// for example through Map, MapErr or ResultAndThen, keep the original trace.
+// While capture is off, creating an Err records nothing.
+//
+// Example:
+//
+//	previous := SetTraceCapture(true)
+//	defer SetTraceCapture(previous)
+//
+//	result := loadUser(id)
+//	fmt.Printf("%+v\n", result) // Err(not found) followed by where it was created
+func SetTraceCapture(enabled bool) (previous bool) {
+	return internal.SetTraceCapture(enabled)
+}
@@ -0,0 +1,82 @@
+package extension_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/shoenig/test/must"
+
+	"codeberg.org/yaadata/opt/core"
+	"codeberg.org/yaadata/opt/extension"
+	"codeberg.org/yaadata/opt/internal"
+)
+
+func failingLookup() core.Result[int] {
+	return internal.Err[int](errors.New("not found"))
+}
+
+// TestSetTraceCapture is not parallel because trace capture is process-wide.
+func TestSetTraceCapture(t *testing.T) {
+	t.Run("Disabled capture records nothing", func(t *testing.T) {
+		// [A]rrange
+		defer extension.SetTraceCapture(extension.SetTraceCapture(false))
+		// [A]ct
+		actual := failingLookup()
+		// [A]ssert
+		must.Nil(t, actual.Trace())
+		must.Eq(t, "Err(not found)", fmt.Sprintf("%+v", actual))
+	})
+
+	t.Run("Err records where it was created", func(t *testing.T) {
+		// [A]rrange
+		defer extension.SetTraceCapture(extension.SetTraceCapture(true))
+		// [A]ct
+		actual := failingLookup()
+		// [A]ssert
+		frames := actual.Trace().Frames()
+		must.StrHasSuffix(t, ".failingLookup", frames[0].Function)
+		must.StrHasSuffix(t, "trace_test.go", frames[0].File)
+	})
+
+	t.Run("ResultFromReturn records where it was called", func(t *testing.T) {
+		// [A]rrange
+		defer extension.SetTraceCapture(extension.SetTraceCapture(true))
+		// [A]ct
+		actual := extension.ResultFromReturn(0, errors.New("boom"))
+		// [A]ssert
+		must.StrContains(t, actual.Trace().Frames()[0].Function, "TestSetTraceCapture")
+	})
+
+	t.Run("Ok results have no trace", func(t *testing.T) {
+		// [A]rrange
+		defer extension.SetTraceCapture(extension.SetTraceCapture(true))
+		// [A]ct
+		actual := extension.ResultFromReturn(1, nil)
+		// [A]ssert
+		must.Nil(t, actual.Trace())
+	})
+
+	t.Run("Derived results keep the original trace", func(t *testing.T) {
+		// [A]rrange
+		defer extension.SetTraceCapture(extension.SetTraceCapture(true))
+		original := failingLookup()
+		// [A]ct
+		mapped := extension.ResultMap(original, func(v int) string { return fmt.Sprint(v) })
+		chained := extension.ResultAndThen(mapped, func(v string) core.Result[bool] { return internal.Ok(true) })
+		wrapped := chained.MapErr(func(err error) error { return fmt.Errorf("lookup: %w", err) })
+		// [A]ssert
+		must.Eq(t, original.Trace(), wrapped.Trace())
+	})
+
+	t.Run("Format with %+v prints the trace", func(t *testing.T) {
+		// [A]rrange
+		defer extension.SetTraceCapture(extension.SetTraceCapture(true))
+		result := failingLookup()
+		// [A]ct
+		actual := fmt.Sprintf("%+v", result)
+		// [A]ssert
+		must.StrHasPrefix(t, "Err(not found)\ncodeberg.org/yaadata/opt/extension_test.failingLookup\n\t", actual)
+		must.Eq(t, "Err(not found)", fmt.Sprintf("%v", result))
+	})
+}
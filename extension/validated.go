@@ -0,0 +1,220 @@
+package extension
+
+import (
+	"strconv"
+
+	"codeberg.org/yaadata/opt/core"
+	"codeberg.org/yaadata/opt/internal"
+)
+
+// ValidatedFromResult converts a Result into a Validated. Ok becomes Valid and
+// Err becomes Invalid with the error. A *core.MultiError is split into the
+// errors it holds.
+//
+// Example:
+//
+//	v := ValidatedFromResult(parsePort(raw)).At("port")
+func ValidatedFromResult[T any](result core.Result[T]) core.Validated[T] {
+	if result.IsOk() {
+		return internal.Valid(result.Unwrap())
+	}
+	return internal.Invalid[T](result.UnwrapErr())
+}
+
+// ValidatedFromResults converts a slice of Results into a Validated slice.
+// It is Valid only if every Result is Ok. Otherwise it holds the error of
+// every Err, with the field path set to its index, such as "[2]".
+//
+// Example:
+//
+//	v := ValidatedFromResults([]core.Result[int]{Ok(1), Err[int](errA), Err[int](errB)})
+//	v.Result().UnwrapErr().Error() // "[1]: a\n[2]: b"
+func ValidatedFromResults[T any](results []core.Result[T]) core.Validated[[]T] {
+	validated := make([]core.Validated[T], len(results))
+	for i, result := range results {
+		validated[i] = ValidatedFromResult(result)
+	}
+	return ValidatedCombine(validated...)
+}
+
+// ValidatedCombine collects the values of every Valid input into a slice.
+// If any input is Invalid, it returns Invalid with the errors of every
+// Invalid input, with the field path set to its index, such as "[2]".
+//
+// Example:
+//
+//	ValidatedCombine(Valid(1), Valid(2)).Result().Unwrap() // [1 2]
+//
+//	v := ValidatedCombine(Valid(1), Invalid[int](errors.New("must be positive")))
+//	v.Result().UnwrapErr().Error() // "[1]: must be positive"
+func ValidatedCombine[T any](validated ...core.Validated[T]) core.Validated[[]T] {
+	values := make([]T, 0, len(validated))
+	var errs []error
+	for i, v := range validated {
+		if v.IsInvalid() {
+			errs = append(errs, v.At("["+strconv.Itoa(i)+"]").Errors()...)
+			continue
+		}
+		values = append(values, validValue(v))
+	}
+	if len(errs) > 0 {
+		return internal.Invalid[[]T](errs...)
+	}
+	return internal.Valid(values)
+}
+
+// ValidatedMap transforms a Valid value with fn. An Invalid value is returned
+// with the same errors.
+//
+// Example:
+//
+//	ValidatedMap(Valid("12345"), strings.TrimSpace)
+func ValidatedMap[A, V any](a core.Validated[A], fn func(A) V) core.Validated[V] {
+	if a.IsInvalid() {
+		return internal.Invalid[V](a.Errors()...)
+	}
+	return internal.Valid(fn(validValue(a)))
+}
+
+// ValidatedMap2 combines two Validated values with fn if both are Valid.
+// Otherwise it returns Invalid with the errors of every Invalid input, so all
+// problems are reported at once.
+//
+// ValidatedMap3 to ValidatedMap8 do the same with more inputs.
+//
+// Example:
+//
+//	address := ValidatedMap2(
+//	    validateStreet(form.Street).At("street"),
+//	    validateZip(form.Zip).At("zip"),
+//	    func(street, zip string) Address { return Address{Street: street, Zip: zip} },
+//	).At("address")
+//	address.Result().UnwrapErr().Error() // "address.street: required\naddress.zip: must be 5 digits"
+func ValidatedMap2[A, B, V any](a core.Validated[A], b core.Validated[B], fn func(A, B) V) core.Validated[V] {
+	if errs := invalidErrors(a, b); len(errs) > 0 {
+		return internal.Invalid[V](errs...)
+	}
+	return internal.Valid(fn(validValue(a), validValue(b)))
+}
+
+// ValidatedMap3 is ValidatedMap2 with three inputs.
+func ValidatedMap3[A, B, C, V any](
+	a core.Validated[A],
+	b core.Validated[B],
+	c core.Validated[C],
+	fn func(A, B, C) V,
+) core.Validated[V] {
+	if errs := invalidErrors(a, b, c); len(errs) > 0 {
+		return internal.Invalid[V](errs...)
+	}
+	return internal.Valid(fn(validValue(a), validValue(b), validValue(c)))
+}
+
+// ValidatedMap4 is ValidatedMap2 with four inputs.
+func ValidatedMap4[A, B, C, D, V any](
+	a core.Validated[A],
+	b core.Validated[B],
+	c core.Validated[C],
+	d core.Validated[D],
+	fn func(A, B, C, D) V,
+) core.Validated[V] {
+	if errs := invalidErrors(a, b, c, d); len(errs) > 0 {
+		return internal.Invalid[V](errs...)
+	}
+	return internal.Valid(fn(validValue(a), validValue(b), validValue(c), validValue(d)))
+}
+
+// ValidatedMap5 is ValidatedMap2 with five inputs.
+func ValidatedMap5[A, B, C, D, E, V any](
+	a core.Validated[A],
+	b core.Validated[B],
+	c core.Validated[C],
+	d core.Validated[D],
+	e core.Validated[E],
+	fn func(A, B, C, D, E) V,
+) core.Validated[V] {
+	if errs := invalidErrors(a, b, c, d, e); len(errs) > 0 {
+		return internal.Invalid[V](errs...)
+	}
+	return internal.Valid(fn(validValue(a), validValue(b), validValue(c), validValue(d), validValue(e)))
+}
+
+// ValidatedMap6 is ValidatedMap2 with six inputs.
+func ValidatedMap6[A, B, C, D, E, F, V any](
+	a core.Validated[A],
+	b core.Validated[B],
+	c core.Validated[C],
+	d core.Validated[D],
+	e core.Validated[E],
+	f core.Validated[F],
+	fn func(A, B, C, D, E, F) V,
+) core.Validated[V] {
+	if errs := invalidErrors(a, b, c, d, e, f); len(errs) > 0 {
+		return internal.Invalid[V](errs...)
+	}
+	return internal.Valid(fn(validValue(a), validValue(b), validValue(c), validValue(d), validValue(e), validValue(f)))
+}
+
+// ValidatedMap7 is ValidatedMap2 with seven inputs.
+func ValidatedMap7[A, B, C, D, E, F, G, V any](
+	a core.Validated[A],
+	b core.Validated[B],
+	c core.Validated[C],
+	d core.Validated[D],
+	e core.Validated[E],
+	f core.Validated[F],
+	g core.Validated[G],
+	fn func(A, B, C, D, E, F, G) V,
+) core.Validated[V] {
+	if errs := invalidErrors(a, b, c, d, e, f, g); len(errs) > 0 {
+		return internal.Invalid[V](errs...)
+	}
+	return internal.Valid(fn(
+		validValue(a),
+		validValue(b),
+		validValue(c),
+		validValue(d),
+		validValue(e),
+		validValue(f),
+		validValue(g),
+	))
+}
+
+// ValidatedMap8 is ValidatedMap2 with eight inputs.
+func ValidatedMap8[A, B, C, D, E, F, G, H, V any](
+	a core.Validated[A],
+	b core.Validated[B],
+	c core.Validated[C],
+	d core.Validated[D],
+	e core.Validated[E],
+	f core.Validated[F],
+	g core.Validated[G],
+	h core.Validated[H],
+	fn func(A, B, C, D, E, F, G, H) V,
+) core.Validated[V] {
+	if errs := invalidErrors(a, b, c, d, e, f, g, h); len(errs) > 0 {
+		return internal.Invalid[V](errs...)
+	}
+	return internal.Valid(fn(
+		validValue(a),
+		validValue(b),
+		validValue(c),
+		validValue(d),
+		validValue(e),
+		validValue(f),
+		validValue(g),
+		validValue(h),
+	))
+}
+
+func validValue[T any](v core.Validated[T]) T {
+	return v.UnwrapOr(*new(T))
+}
+
+func invalidErrors(validated ...interface{ Errors() []error }) []error {
+	var errs []error
+	for _, v := range validated {
+		errs = append(errs, v.Errors()...)
+	}
+	return errs
+}
@@ -0,0 +1,134 @@
+package extension_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/shoenig/test/must"
+
+	"codeberg.org/yaadata/opt/core"
+	"codeberg.org/yaadata/opt/extension"
+	"codeberg.org/yaadata/opt/internal"
+)
+
+func TestValidatedMap2(t *testing.T) {
+	t.Parallel()
+	type Address struct {
+		Street string
+		Zip    string
+	}
+	build := func(street, zip string) Address {
+		return Address{Street: street, Zip: zip}
+	}
+
+	t.Run("Every input Valid", func(t *testing.T) {
+		t.Parallel()
+		// [A]rrange
+		street := internal.Valid("Main St")
+		zip := internal.Valid("12345")
+		// [A]ct
+		actual := extension.ValidatedMap2(street, zip, build)
+		// [A]ssert
+		must.Eq(t, Address{Street: "Main St", Zip: "12345"}, actual.Result().Unwrap())
+	})
+
+	t.Run("Every error is kept", func(t *testing.T) {
+		t.Parallel()
+		// [A]rrange
+		street := internal.Invalid[string](errors.New("required")).At("street")
+		zip := internal.Invalid[string](errors.New("must be 5 digits")).At("zip")
+		// [A]ct
+		actual := extension.ValidatedMap2(street, zip, build).At("address")
+		// [A]ssert
+		must.EqError(t, actual.Result().UnwrapErr(), "address.street: required\naddress.zip: must be 5 digits")
+	})
+}
+
+func TestValidatedMap8(t *testing.T) {
+	t.Parallel()
+	t.Run("Errors are kept in input order", func(t *testing.T) {
+		t.Parallel()
+		// [A]rrange
+		ok := internal.Valid(1)
+		bad := func(name string) core.Validated[int] {
+			return internal.Invalid[int](errors.New("invalid")).At(name)
+		}
+		sum := func(a, b, c, d, e, f, g, h int) int { return a + b + c + d + e + f + g + h }
+		// [A]ct
+		actual := extension.ValidatedMap8(ok, bad("b"), ok, ok, bad("e"), ok, ok, bad("h"), sum)
+		// [A]ssert
+		must.EqError(t, actual.Result().UnwrapErr(), "b: invalid\ne: invalid\nh: invalid")
+	})
+
+	t.Run("Every input Valid", func(t *testing.T) {
+		t.Parallel()
+		// [A]rrange
+		ok := internal.Valid(1)
+		sum := func(a, b, c, d, e, f, g, h int) int { return a + b + c + d + e + f + g + h }
+		// [A]ct
+		actual := extension.ValidatedMap8(ok, ok, ok, ok, ok, ok, ok, ok, sum)
+		// [A]ssert
+		must.Eq(t, 8, actual.UnwrapOr(0))
+	})
+}
+
+func TestValidatedMap(t *testing.T) {
+	t.Parallel()
+	t.Run("Invalid keeps errors", func(t *testing.T) {
+		t.Parallel()
+		// [A]rrange
+		v := internal.Invalid[int](errors.New("a"), errors.New("b"))
+		// [A]ct
+		actual := extension.ValidatedMap(v, func(value int) string { return "unused" })
+		// [A]ssert
+		must.Eq(t, v.Errors(), actual.Errors())
+	})
+}
+
+func TestValidatedCombine(t *testing.T) {
+	t.Parallel()
+	t.Run("Every input Valid", func(t *testing.T) {
+		t.Parallel()
+		// [A]ct
+		actual := extension.ValidatedCombine(internal.Valid(1), internal.Valid(2))
+		// [A]ssert
+		must.Eq(t, []int{1, 2}, actual.Result().Unwrap())
+	})
+
+	t.Run("Errors carry their index", func(t *testing.T) {
+		t.Parallel()
+		// [A]rrange
+		bad := internal.Invalid[int](errors.New("must be positive")).At("qty")
+		// [A]ct
+		actual := extension.ValidatedCombine(internal.Valid(1), bad).At("items")
+		// [A]ssert
+		must.EqError(t, actual.Result().UnwrapErr(), "items[1].qty: must be positive")
+	})
+}
+
+func TestValidatedFromResults(t *testing.T) {
+	t.Parallel()
+	t.Run("Every Result Ok", func(t *testing.T) {
+		t.Parallel()
+		// [A]rrange
+		results := []core.Result[int]{internal.Ok(1), internal.Ok(2)}
+		// [A]ct
+		actual := extension.ValidatedFromResults(results)
+		// [A]ssert
+		must.Eq(t, []int{1, 2}, actual.Result().Unwrap())
+	})
+
+	t.Run("Every Err is collected", func(t *testing.T) {
+		t.Parallel()
+		// [A]rrange
+		results := []core.Result[int]{
+			internal.Ok(1),
+			internal.Err[int](errors.New("a")),
+			internal.Err[int](errors.New("b")),
+		}
+		// [A]ct
+		actual := extension.ValidatedFromResults(results)
+		// [A]ssert
+		must.EqError(t, actual.Result().UnwrapErr(), "[1]: a\n[2]: b")
+	})
+}
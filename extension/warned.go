@@ -0,0 +1,37 @@
+package extension
+
+import (
+	"slices"
+
+	"codeberg.org/yaadata/opt/core"
+	"codeberg.org/yaadata/opt/internal"
+)
+
+// WarnedMap transforms the Ok value of warned with fn, keeping its warnings.
+// An Err is kept with the same error and warnings.
+//
+// Example:
+//
+//	w := WithWarnings(Ok(3), ErrTruncated)
+//	mapped := WarnedMap(w, strconv.Itoa)
+//	mapped.Result().Unwrap() // "3"
+//	mapped.Warnings()        // [ErrTruncated]
+func WarnedMap[T, V any](warned core.Warned[T], fn func(value T) V) core.Warned[V] {
+	return internal.WithWarnings(internal.ResultMap(warned.Result(), fn), warned.Warnings()...)
+}
+
+// WarnedAndThen calls fn with the Ok value of warned and returns its result
+// with the warnings of both. If warned is Err, fn is not called and the Err
+// is kept with its warnings.
+//
+// Example:
+//
+//	w := WarnedAndThen(parseRecord(line), normalize)
+//	w.Warnings() // warnings from parseRecord followed by those from normalize
+func WarnedAndThen[T, V any](warned core.Warned[T], fn func(value T) core.Warned[V]) core.Warned[V] {
+	if warned.Result().IsError() {
+		return internal.WithWarnings(internal.ErrFrom[V](warned.Result()), warned.Warnings()...)
+	}
+	next := fn(warned.Result().Unwrap())
+	return internal.WithWarnings(next.Result(), slices.Concat(warned.Warnings(), next.Warnings())...)
+}
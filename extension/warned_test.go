@@ -0,0 +1,72 @@
+package extension_test
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/shoenig/test/must"
+
+	"codeberg.org/yaadata/opt/core"
+	"codeberg.org/yaadata/opt/extension"
+	"codeberg.org/yaadata/opt/internal"
+)
+
+func TestWarnedMap(t *testing.T) {
+	t.Parallel()
+	errTruncated := errors.New("record was truncated")
+	t.Run("Ok keeps warnings", func(t *testing.T) {
+		t.Parallel()
+		// [A]rrange
+		w := internal.WithWarnings(internal.Ok(3), errTruncated)
+		// [A]ct
+		actual := extension.WarnedMap(w, strconv.Itoa)
+		// [A]ssert
+		must.Eq(t, "3", actual.Result().Unwrap())
+		must.Eq(t, []error{errTruncated}, actual.Warnings())
+	})
+
+	t.Run("Err keeps error and warnings", func(t *testing.T) {
+		t.Parallel()
+		// [A]rrange
+		cause := errors.New("failed")
+		w := internal.WithWarnings(internal.Err[int](cause), errTruncated)
+		// [A]ct
+		actual := extension.WarnedMap(w, strconv.Itoa)
+		// [A]ssert
+		must.Eq(t, cause, actual.Result().UnwrapErr())
+		must.Eq(t, []error{errTruncated}, actual.Warnings())
+	})
+}
+
+func TestWarnedAndThen(t *testing.T) {
+	t.Parallel()
+	errDeprecated := errors.New("field is deprecated")
+	errTruncated := errors.New("record was truncated")
+	normalize := func(value int) core.Warned[string] {
+		return internal.WithWarnings(internal.Ok(strconv.Itoa(value)), errTruncated)
+	}
+
+	t.Run("Warnings of both steps are kept in order", func(t *testing.T) {
+		t.Parallel()
+		// [A]rrange
+		w := internal.WithWarnings(internal.Ok(3), errDeprecated)
+		// [A]ct
+		actual := extension.WarnedAndThen(w, normalize)
+		// [A]ssert
+		must.Eq(t, "3", actual.Result().Unwrap())
+		must.Eq(t, []error{errDeprecated, errTruncated}, actual.Warnings())
+	})
+
+	t.Run("Err skips the step", func(t *testing.T) {
+		t.Parallel()
+		// [A]rrange
+		cause := errors.New("failed")
+		w := internal.WithWarnings(internal.Err[int](cause), errDeprecated)
+		// [A]ct
+		actual := extension.WarnedAndThen(w, normalize)
+		// [A]ssert
+		must.Eq(t, cause, actual.Result().UnwrapErr())
+		must.Eq(t, []error{errDeprecated}, actual.Warnings())
+	})
+}
@@ -0,0 +1,94 @@
+package extension
+
+import (
+	"errors"
+
+	"codeberg.org/yaadata/opt/core"
+	"codeberg.org/yaadata/opt/internal"
+)
+
+// OptionZip returns Some(Pair) of both values if both Options are Some,
+// otherwise None.
+//
+// Example:
+//
+//	OptionZip(Some("port"), Some(8080)) // Some(Pair{"port", 8080})
+//	OptionZip(Some("port"), None[int]()) // None
+func OptionZip[A, B any](a core.Option[A], b core.Option[B]) core.Option[core.Pair[A, B]] {
+	return OptionZipWith(a, b, func(first A, second B) core.Pair[A, B] {
+		return core.Pair[A, B]{First: first, Second: second}
+	})
+}
+
+// OptionZip3 returns Some(Triple) of all three values if every Option is
+// Some, otherwise None.
+//
+// Example:
+//
+//	OptionZip3(Some("host"), Some(8080), Some(true)) // Some(Triple{"host", 8080, true})
+func OptionZip3[A, B, C any](a core.Option[A], b core.Option[B], c core.Option[C]) core.Option[core.Triple[A, B, C]] {
+	if a.IsNone() || b.IsNone() || c.IsNone() {
+		return internal.None[core.Triple[A, B, C]]()
+	}
+	return internal.Some(core.Triple[A, B, C]{First: a.Unwrap(), Second: b.Unwrap(), Third: c.Unwrap()})
+}
+
+// OptionZipWith combines both values with fn if both Options are Some,
+// otherwise it returns None.
+//
+// Example:
+//
+//	OptionZipWith(Some("localhost"), Some("8080"), net.JoinHostPort) // Some("localhost:8080")
+func OptionZipWith[A, B, V any](a core.Option[A], b core.Option[B], fn func(first A, second B) V) core.Option[V] {
+	if a.IsNone() || b.IsNone() {
+		return internal.None[V]()
+	}
+	return internal.Some(fn(a.Unwrap(), b.Unwrap()))
+}
+
+// OptionUnzip splits Some(Pair) into Some of each value, and None into two Nones.
+//
+// Example:
+//
+//	key, value := OptionUnzip(Some(Pair[string, int]{"port", 8080})) // Some("port"), Some(8080)
+func OptionUnzip[A, B any](option core.Option[core.Pair[A, B]]) (core.Option[A], core.Option[B]) {
+	if option.IsNone() {
+		return internal.None[A](), internal.None[B]()
+	}
+	pair := option.Unwrap()
+	return internal.Some(pair.First), internal.Some(pair.Second)
+}
+
+// ResultZip returns Ok(Pair) of both values if both Results are Ok.
+// Otherwise it returns Err; if both are Err, the errors are joined with
+// errors.Join.
+//
+// Example:
+//
+//	ResultZip(fetchUser(id), fetchOrg(orgID)) // Ok(Pair{user, org})
+func ResultZip[A, B any](a core.Result[A], b core.Result[B]) core.Result[core.Pair[A, B]] {
+	return ResultZipWith(a, b, func(first A, second B) core.Pair[A, B] {
+		return core.Pair[A, B]{First: first, Second: second}
+	})
+}
+
+// ResultZipWith combines both values with fn if both Results are Ok.
+// Otherwise it returns Err like ResultZip.
+//
+// Example:
+//
+//	ResultZipWith(lookupHost(name), lookupPort(name), net.JoinHostPort) // Ok("db:5432")
+func ResultZipWith[A, B, V any](a core.Result[A], b core.Result[B], fn func(first A, second B) V) core.Result[V] {
+	switch {
+	case a.IsOk() && b.IsOk():
+		return internal.Ok(fn(a.Unwrap(), b.Unwrap()))
+	case a.IsError() && b.IsError():
+		return internal.ErrFrom[V](a.MapErr(func(err error) error {
+			return errors.Join(err, b.UnwrapErr())
+		}))
+	case a.IsError():
+		return internal.ErrFrom[V](a)
+	default:
+		return internal.ErrFrom[V](b)
+	}
+}
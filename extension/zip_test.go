@@ -0,0 +1,133 @@
+package extension_test
+
+import (
+	"errors"
+	"net"
+	"testing"
+
+	"github.com/shoenig/test/must"
+
+	"codeberg.org/yaadata/opt/core"
+	"codeberg.org/yaadata/opt/extension"
+	"codeberg.org/yaadata/opt/internal"
+)
+
+func TestOptionZip(t *testing.T) {
+	t.Parallel()
+	t.Run("Both Some", func(t *testing.T) {
+		t.Parallel()
+		// [A]ct
+		actual := extension.OptionZip(internal.Some("port"), internal.Some(8080))
+		// [A]ssert
+		must.Eq(t, core.Pair[string, int]{First: "port", Second: 8080}, actual.Unwrap())
+	})
+
+	t.Run("Either None", func(t *testing.T) {
+		t.Parallel()
+		// [A]ct
+		first := extension.OptionZip(internal.None[string](), internal.Some(8080))
+		second := extension.OptionZip(internal.Some("port"), internal.None[int]())
+		// [A]ssert
+		must.True(t, first.IsNone())
+		must.True(t, second.IsNone())
+	})
+}
+
+func TestOptionZip3(t *testing.T) {
+	t.Parallel()
+	t.Run("All Some", func(t *testing.T) {
+		t.Parallel()
+		// [A]ct
+		actual := extension.OptionZip3(internal.Some("host"), internal.Some(8080), internal.Some(true))
+		// [A]ssert
+		host, port, tls := actual.Unwrap().Unpack()
+		must.Eq(t, "host", host)
+		must.Eq(t, 8080, port)
+		must.True(t, tls)
+	})
+
+	t.Run("Any None", func(t *testing.T) {
+		t.Parallel()
+		// [A]ct
+		actual := extension.OptionZip3(internal.Some("host"), internal.Some(8080), internal.None[bool]())
+		// [A]ssert
+		must.True(t, actual.IsNone())
+	})
+}
+
+func TestOptionZipWith(t *testing.T) {
+	t.Parallel()
+	t.Run("Both Some are combined", func(t *testing.T) {
+		t.Parallel()
+		// [A]ct
+		actual := extension.OptionZipWith(internal.Some("localhost"), internal.Some("8080"), net.JoinHostPort)
+		// [A]ssert
+		must.Eq(t, "localhost:8080", actual.Unwrap())
+	})
+}
+
+func TestOptionUnzip(t *testing.T) {
+	t.Parallel()
+	t.Run("Some splits into two Somes", func(t *testing.T) {
+		t.Parallel()
+		// [A]ct
+		key, value := extension.OptionUnzip(internal.Some(core.Pair[string, int]{First: "port", Second: 8080}))
+		// [A]ssert
+		must.Eq(t, "port", key.Unwrap())
+		must.Eq(t, 8080, value.Unwrap())
+	})
+
+	t.Run("None splits into two Nones", func(t *testing.T) {
+		t.Parallel()
+		// [A]ct
+		key, value := extension.OptionUnzip(internal.None[core.Pair[string, int]]())
+		// [A]ssert
+		must.True(t, key.IsNone())
+		must.True(t, value.IsNone())
+	})
+}
+
+func TestResultZip(t *testing.T) {
+	t.Parallel()
+	t.Run("Both Ok", func(t *testing.T) {
+		t.Parallel()
+		// [A]ct
+		actual := extension.ResultZip(internal.Ok("port"), internal.Ok(8080))
+		// [A]ssert
+		must.Eq(t, core.Pair[string, int]{First: "port", Second: 8080}, actual.Unwrap())
+	})
+
+	t.Run("One Err", func(t *testing.T) {
+		t.Parallel()
+		// [A]rrange
+		cause := errors.New("no port")
+		// [A]ct
+		actual := extension.ResultZip(internal.Ok("port"), internal.Err[int](cause))
+		// [A]ssert
+		must.Eq(t, cause, actual.UnwrapErr())
+	})
+
+	t.Run("Both Err are joined", func(t *testing.T) {
+		t.Parallel()
+		// [A]rrange
+		errHost := errors.New("no host")
+		errPort := errors.New("no port")
+		// [A]ct
+		actual := extension.ResultZip(internal.Err[string](errHost), internal.Err[int](errPort))
+		// [A]ssert
+		must.EqError(t, actual.UnwrapErr(), "no host\nno port")
+		must.ErrorIs(t, actual.UnwrapErr(), errHost)
+		must.ErrorIs(t, actual.UnwrapErr(), errPort)
+	})
+}
+
+func TestResultZipWith(t *testing.T) {
+	t.Parallel()
+	t.Run("Both Ok are combined", func(t *testing.T) {
+		t.Parallel()
+		// [A]ct
+		actual := extension.ResultZipWith(internal.Ok("db"), internal.Ok("5432"), net.JoinHostPort)
+		// [A]ssert
+		must.Eq(t, "db:5432", actual.Unwrap())
+	})
+}
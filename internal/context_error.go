@@ -0,0 +1,31 @@
+package internal
+
+// ContextError adds a message in front of the error it wraps.
+type ContextError struct {
+	Msg string
+	Err error
+}
+
+// FieldError attaches a key/value pair to the error it wraps without
+// changing its message.
+type FieldError struct {
+	Key   string
+	Value any
+	Err   error
+}
+
+func (e *ContextError) Error() string {
+	return e.Msg + ": " + e.Err.Error()
+}
+
+func (e *ContextError) Unwrap() error {
+	return e.Err
+}
+
+func (e *FieldError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
@@ -49,6 +49,13 @@ func (o *option[T]) IsSomeAnd(pred shared.Predicate[T]) bool {
 	return false
 }
 
+func (o *option[T]) Kind() core.OptionKind {
+	if o.IsSome() {
+		return core.KindSome
+	}
+	return core.KindNone
+}
+
 func (o *option[T]) IsNone() bool {
 	return o.value == nil
 }
@@ -75,14 +82,33 @@ func (o *option[T]) Equal(other core.Option[T]) bool {
 }
 
 func (o *option[T]) Expect(msg string) T {
+	return o.expect("Option.Expect", msg)
+}
+
+func (o *option[T]) Get() (T, bool) {
 	if o.value == nil {
-		panic(msg)
+		return *new(T), false
 	}
-	return *o.value
+	return *o.value, true
+}
+
+func (o *option[T]) ToPointer() *T {
+	if o.value == nil {
+		return nil
+	}
+	value := *o.value
+	return &value
 }
 
 func (o *option[T]) Unwrap() T {
-	return o.Expect(_FAILED_UNWRAP)
+	return o.expect("Option.Unwrap", _FAILED_UNWRAP)
+}
+
+func (o *option[T]) expect(method, msg string) T {
+	if o.value == nil {
+		panic(newUnwrapError(method, msg, core.UnwrapKindNone, nil))
+	}
+	return *o.value
 }
 
 func (o *option[T]) UnwrapOrElse(fn func() T) T {
@@ -0,0 +1,70 @@
+package internal
+
+import "codeberg.org/yaadata/opt/core"
+
+type optionResult[T any] struct {
+	result core.Result[core.Option[T]]
+}
+
+// interface guard
+var _ core.OptionResult[string] = (*optionResult[string])(nil)
+
+func OptionResultFromResult[T any](result core.Result[core.Option[T]]) core.OptionResult[T] {
+	return &optionResult[T]{result: result}
+}
+
+func Found[T any](value T) core.OptionResult[T] {
+	return OptionResultFromResult(Ok(Some(value)))
+}
+
+func Absent[T any]() core.OptionResult[T] {
+	return OptionResultFromResult(Ok(None[T]()))
+}
+
+func Failed[T any](err error) core.OptionResult[T] {
+	return OptionResultFromResult(Err[core.Option[T]](err))
+}
+
+func (o *optionResult[T]) Err() core.Option[error] {
+	return o.result.Err()
+}
+
+func (o *optionResult[T]) IsError() bool {
+	return o.result.IsError()
+}
+
+func (o *optionResult[T]) IsNone() bool {
+	return o.result.IsOkAnd(core.Option[T].IsNone)
+}
+
+func (o *optionResult[T]) IsSome() bool {
+	return o.result.IsOkAnd(core.Option[T].IsSome)
+}
+
+func (o *optionResult[T]) Option() core.Option[core.Result[T]] {
+	if o.IsError() {
+		return Some(ErrFrom[T](o.result))
+	}
+	if o.IsNone() {
+		return None[core.Result[T]]()
+	}
+	return Some(Ok(o.result.Unwrap().Unwrap()))
+}
+
+func (o *optionResult[T]) OrNotFound(err error) core.Result[T] {
+	if o.IsError() {
+		return ErrFrom[T](o.result)
+	}
+	return o.result.Unwrap().OkOr(err)
+}
+
+func (o *optionResult[T]) Result() core.Result[core.Option[T]] {
+	return o.result
+}
+
+func (o *optionResult[T]) UnwrapOrDefault() T {
+	if o.IsSome() {
+		return o.result.Unwrap().Unwrap()
+	}
+	return *new(T)
+}
@@ -0,0 +1,110 @@
+package internal
+
+import (
+	"fmt"
+
+	"codeberg.org/yaadata/opt/core"
+)
+
+type outcome struct {
+	err   error
+	trace core.Trace
+}
+
+// interface guard
+var _ core.Outcome = (*outcome)(nil)
+
+func Success() core.Outcome {
+	return &outcome{}
+}
+
+func OutcomeFromError(err error) core.Outcome {
+	if err != nil {
+		return &outcome{
+			err:   err,
+			trace: captureTrace(),
+		}
+	}
+	return &outcome{}
+}
+
+// OutcomeFromResult drops the Ok value of res, keeping the error and trace of an Err.
+func OutcomeFromResult[T any](res core.Result[T]) core.Outcome {
+	if res.IsOk() {
+		return &outcome{}
+	}
+	return &outcome{
+		err:   res.UnwrapErr(),
+		trace: res.Trace(),
+	}
+}
+
+// ErrFromOutcome converts a Failure into an Err, keeping the error and the
+// trace recorded where the Failure was created.
+func ErrFromOutcome[T any](o core.Outcome) core.Result[T] {
+	return &result[T]{
+		value: nil,
+		err:   o.Error(),
+		trace: o.Trace(),
+	}
+}
+
+func (o *outcome) AndThen(fn func() core.Outcome) core.Outcome {
+	if o.IsSuccess() {
+		return fn()
+	}
+	return o
+}
+
+func (o *outcome) Error() error {
+	return o.err
+}
+
+func (o *outcome) InspectErr(fn func(err error)) core.Outcome {
+	if o.IsFailure() {
+		fn(o.err)
+	}
+	return o
+}
+
+func (o *outcome) IsFailure() bool {
+	return o.err != nil
+}
+
+func (o *outcome) IsSuccess() bool {
+	return o.err == nil
+}
+
+func (o *outcome) MapErr(fn func(err error) error) core.Outcome {
+	if o.IsFailure() {
+		return &outcome{
+			err:   fn(o.err),
+			trace: o.trace,
+		}
+	}
+	return o
+}
+
+func (o *outcome) OrElse(fn func(err error) core.Outcome) core.Outcome {
+	if o.IsFailure() {
+		return fn(o.err)
+	}
+	return o
+}
+
+func (o *outcome) Trace() core.Trace {
+	return o.trace
+}
+
+// Format prints Success or Failure(error) using the verb and flags it was
+// called with. With %+v a Failure also prints the trace recorded when it was created.
+func (o *outcome) Format(f fmt.State, verb rune) {
+	if o.IsSuccess() {
+		fmt.Fprint(f, "Success")
+		return
+	}
+	fmt.Fprintf(f, "Failure("+fmt.FormatString(f, verb)+")", o.err)
+	if verb == 'v' && f.Flag('+') && len(o.trace) > 0 {
+		fmt.Fprintf(f, "\n%s", o.trace)
+	}
+}
@@ -0,0 +1,86 @@
+package internal
+
+import (
+	"runtime"
+	"runtime/debug"
+	"slices"
+	"sync"
+	"sync/atomic"
+
+	"codeberg.org/yaadata/opt/core"
+)
+
+var (
+	// unwrapHooks is nil while no hook is registered so that panicking stays
+	// a single atomic load away from the hook-free path.
+	unwrapHooks   atomic.Pointer[[]*unwrapHook]
+	unwrapHooksMu sync.Mutex
+)
+
+type unwrapHook struct {
+	fn func(*core.UnwrapError)
+}
+
+// RegisterUnwrapHook adds fn to the hooks run before an unwrap panic
+// propagates and returns a function that removes it again.
+func RegisterUnwrapHook(fn func(*core.UnwrapError)) func() {
+	hook := &unwrapHook{fn: fn}
+	unwrapHooksMu.Lock()
+	defer unwrapHooksMu.Unlock()
+	var hooks []*unwrapHook
+	if current := unwrapHooks.Load(); current != nil {
+		hooks = slices.Clone(*current)
+	}
+	hooks = append(hooks, hook)
+	unwrapHooks.Store(&hooks)
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			unwrapHooksMu.Lock()
+			defer unwrapHooksMu.Unlock()
+			remaining := slices.DeleteFunc(slices.Clone(*unwrapHooks.Load()), func(h *unwrapHook) bool {
+				return h == hook
+			})
+			if len(remaining) == 0 {
+				unwrapHooks.Store(nil)
+				return
+			}
+			unwrapHooks.Store(&remaining)
+		})
+	}
+}
+
+func newUnwrapError(method, msg string, kind core.UnwrapKind, err error) *core.UnwrapError {
+	unwrapErr := &core.UnwrapError{
+		Method:  method,
+		Message: msg,
+		Kind:    kind,
+		Err:     err,
+		Caller:  caller(),
+	}
+	if hooks := unwrapHooks.Load(); hooks != nil {
+		unwrapErr.Stack = debug.Stack()
+		for _, hook := range *hooks {
+			hook.fn(unwrapErr)
+		}
+	}
+	return unwrapErr
+}
+
+// caller returns the first frame outside the library, which is the code
+// that called Unwrap or Expect.
+func caller() runtime.Frame {
+	var pcs [16]uintptr
+	n := runtime.Callers(3, pcs[:])
+	frames := runtime.CallersFrames(pcs[:n])
+	for {
+		frame, more := frames.Next()
+		if !isLibraryFrame(frame.Function) {
+			return frame
+		}
+		if !more {
+			return runtime.Frame{}
+		}
+	}
+}
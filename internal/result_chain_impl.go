@@ -6,7 +6,7 @@ func ResultMap[T, V any](result core.Result[T], fn func(inner T) V) core.Result[
 	if result.IsOk() {
 		return Ok(fn(result.Unwrap()))
 	}
-	return Err[V](result.UnwrapErr())
+	return ErrFrom[V](result)
 }
 
 func ResultMapOr[T, V any](result core.Result[T], fn func(inner T) V, or V) core.Result[V] {
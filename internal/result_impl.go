@@ -1,6 +1,9 @@
 package internal
 
 import (
+	"errors"
+	"fmt"
+
 	"codeberg.org/yaadata/opt/core"
 	"codeberg.org/yaadata/opt/shared"
 )
@@ -8,6 +11,7 @@ import (
 type result[T any] struct {
 	value *T
 	err   error
+	trace core.Trace
 }
 
 // interface guard
@@ -15,7 +19,11 @@ var _ core.Result[string] = (*result[string])(nil)
 
 func ResultFromReturn[T any](value T, err error) core.Result[T] {
 	if err != nil {
-		return Err[T](err)
+		return &result[T]{
+			value: nil,
+			err:   err,
+			trace: captureTrace(),
+		}
 	}
 	return &result[T]{
 		value: &value,
@@ -27,6 +35,17 @@ func Err[T any](err error) core.Result[T] {
 	return &result[T]{
 		value: nil,
 		err:   err,
+		trace: captureTrace(),
+	}
+}
+
+// ErrFrom converts an Err result into an Err of another type, keeping the
+// error and the trace recorded where the original Err was created.
+func ErrFrom[V, T any](res core.Result[T]) core.Result[V] {
+	return &result[V]{
+		value: nil,
+		err:   res.UnwrapErr(),
+		trace: res.Trace(),
 	}
 }
 
@@ -45,15 +64,30 @@ func (r *result[T]) Ok() core.Option[T] {
 }
 
 func (r *result[T]) Expect(msg string) T {
+	return r.expect("Result.Expect", msg)
+}
+
+func (r *result[T]) ExpectErr(msg string) error {
+	return r.expectErr("Result.ExpectErr", msg)
+}
+
+func (r *result[T]) Get() (T, error) {
 	if r.IsError() {
-		panic(msg)
+		return *new(T), r.err
+	}
+	return *r.value, nil
+}
+
+func (r *result[T]) expect(method, msg string) T {
+	if r.IsError() {
+		panic(newUnwrapError(method, msg, core.UnwrapKindErr, r.err))
 	}
 	return *r.value
 }
 
-func (r *result[T]) ExpectErr(msg string) error {
+func (r *result[T]) expectErr(method, msg string) error {
 	if r.IsOk() {
-		panic(msg)
+		panic(newUnwrapError(method, msg, core.UnwrapKindOk, nil))
 	}
 	return r.err
 }
@@ -87,6 +121,29 @@ func (r *result[T]) IsErrorAnd(pred shared.Predicate[error]) bool {
 	return false
 }
 
+func (r *result[T]) IsErrorIs(target error) bool {
+	return r.IsError() && errors.Is(r.err, target)
+}
+
+func (r *result[T]) Kind() core.ResultKind {
+	if r.IsOk() {
+		return core.KindOk
+	}
+	return core.KindErr
+}
+
+func (r *result[T]) Context(msg string) core.Result[T] {
+	return r.MapErr(func(err error) error {
+		return &ContextError{Msg: msg, Err: err}
+	})
+}
+
+func (r *result[T]) Contextf(format string, args ...any) core.Result[T] {
+	return r.MapErr(func(err error) error {
+		return &ContextError{Msg: fmt.Sprintf(format, args...), Err: err}
+	})
+}
+
 func (r *result[T]) Inspect(fn func(value T)) core.Result[T] {
 	if r.IsOk() {
 		fn(r.Unwrap())
@@ -115,7 +172,11 @@ func (r *result[T]) MapOrElse(fn func(value T) any, orElse func(err error) any)
 
 func (r *result[T]) MapErr(fn func(inner error) error) core.Result[T] {
 	if r.IsError() {
-		return Err[T](fn(r.UnwrapErr()))
+		return &result[T]{
+			value: nil,
+			err:   fn(r.err),
+			trace: r.trace,
+		}
 	}
 	return r
 }
@@ -134,12 +195,19 @@ func (r *result[T]) OrElse(fn func(err error) core.Result[T]) core.Result[T] {
 	return r
 }
 
+func (r *result[T]) RecoverIs(target error, fn func(err error) core.Result[T]) core.Result[T] {
+	if r.IsErrorIs(target) {
+		return fn(r.err)
+	}
+	return r
+}
+
 func (r *result[T]) Unwrap() T {
-	return r.Expect("cannot unwrap Err result to value")
+	return r.expect("Result.Unwrap", "cannot unwrap Err result to value")
 }
 
 func (r *result[T]) UnwrapErr() error {
-	return r.ExpectErr("cannot unwrap Ok result to error")
+	return r.expectErr("Result.UnwrapErr", "cannot unwrap Ok result to error")
 }
 
 func (r *result[T]) UnwrapOr(val T) T {
@@ -162,3 +230,26 @@ func (r *result[T]) UnwrapOrDefault() T {
 	}
 	return *r.value
 }
+
+func (r *result[T]) WithField(key string, value any) core.Result[T] {
+	return r.MapErr(func(err error) error {
+		return &FieldError{Key: key, Value: value, Err: err}
+	})
+}
+
+func (r *result[T]) Trace() core.Trace {
+	return r.trace
+}
+
+// Format prints Ok(value) or Err(error) using the verb and flags it was
+// called with. With %+v an Err also prints the trace recorded when it was created.
+func (r *result[T]) Format(f fmt.State, verb rune) {
+	if r.IsOk() {
+		fmt.Fprintf(f, "Ok("+fmt.FormatString(f, verb)+")", *r.value)
+		return
+	}
+	fmt.Fprintf(f, "Err("+fmt.FormatString(f, verb)+")", r.err)
+	if verb == 'v' && f.Flag('+') && len(r.trace) > 0 {
+		fmt.Fprintf(f, "\n%s", r.trace)
+	}
+}
@@ -0,0 +1,60 @@
+package internal
+
+import (
+	"runtime"
+	"slices"
+	"strings"
+	"sync/atomic"
+
+	"codeberg.org/yaadata/opt/core"
+)
+
+const (
+	_MAX_TRACE_DEPTH = 32
+	_MODULE          = "codeberg.org/yaadata/opt"
+)
+
+// traceEnabled starts out as _TRACE_DEFAULT, which the opttrace build tag sets.
+var traceEnabled atomic.Bool
+
+func init() {
+	traceEnabled.Store(_TRACE_DEFAULT)
+}
+
+// SetTraceCapture turns trace capture for new Err results on or off and
+// reports whether it was on before.
+func SetTraceCapture(enabled bool) bool {
+	return traceEnabled.Swap(enabled)
+}
+
+// captureTrace records the stack above the library frames, or returns nil
+// when capture is off.
+func captureTrace() core.Trace {
+	if !traceEnabled.Load() {
+		return nil
+	}
+	var pcs [_MAX_TRACE_DEPTH]uintptr
+	n := runtime.Callers(2, pcs[:])
+	trace := pcs[:n]
+	frames := runtime.CallersFrames(trace)
+	skip := 0
+	for {
+		frame, more := frames.Next()
+		if !isLibraryFrame(frame.Function) || !more {
+			break
+		}
+		skip++
+	}
+	return slices.Clone(trace[skip:])
+}
+
+// isLibraryFrame reports whether function belongs to the root, internal or
+// extension package, whose frames are noise at the top of a trace.
+func isLibraryFrame(function string) bool {
+	for _, pkg := range []string{_MODULE + ".", _MODULE + "/internal.", _MODULE + "/extension."} {
+		if strings.HasPrefix(function, pkg) {
+			return true
+		}
+	}
+	return false
+}
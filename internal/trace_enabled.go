@@ -0,0 +1,7 @@
+//go:build opttrace
+
+package internal
+
+const (
+	_TRACE_DEFAULT = true
+)
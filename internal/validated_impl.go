@@ -0,0 +1,88 @@
+package internal
+
+import (
+	"strings"
+
+	"codeberg.org/yaadata/opt/core"
+)
+
+type validated[T any] struct {
+	value *T
+	errs  []error
+}
+
+// interface guard
+var _ core.Validated[string] = (*validated[string])(nil)
+
+func Valid[T any](value T) core.Validated[T] {
+	return &validated[T]{value: &value}
+}
+
+// Invalid panics if errs is empty. A *core.MultiError in errs is flattened
+// into the errors it holds.
+func Invalid[T any](errs ...error) core.Validated[T] {
+	if len(errs) == 0 {
+		panic("opt: Invalid requires at least one error")
+	}
+	flat := make([]error, 0, len(errs))
+	for _, err := range errs {
+		if multi, ok := err.(*core.MultiError); ok {
+			flat = append(flat, multi.Errors...)
+			continue
+		}
+		flat = append(flat, err)
+	}
+	return &validated[T]{errs: flat}
+}
+
+func (v *validated[T]) At(segment string) core.Validated[T] {
+	if v.IsValid() {
+		return v
+	}
+	errs := make([]error, len(v.errs))
+	for i, err := range v.errs {
+		if fieldErr, ok := err.(*core.ValidationError); ok {
+			errs[i] = &core.ValidationError{Path: joinPath(segment, fieldErr.Path), Err: fieldErr.Err}
+			continue
+		}
+		errs[i] = &core.ValidationError{Path: segment, Err: err}
+	}
+	return &validated[T]{errs: errs}
+}
+
+func (v *validated[T]) Errors() []error {
+	return v.errs
+}
+
+func (v *validated[T]) IsInvalid() bool {
+	return v.value == nil
+}
+
+func (v *validated[T]) IsValid() bool {
+	return v.value != nil
+}
+
+func (v *validated[T]) Result() core.Result[T] {
+	if v.IsValid() {
+		return Ok(*v.value)
+	}
+	return Err[T](&core.MultiError{Errors: v.errs})
+}
+
+func (v *validated[T]) UnwrapOr(value T) T {
+	if v.IsValid() {
+		return *v.value
+	}
+	return value
+}
+
+func joinPath(segment, path string) string {
+	switch {
+	case path == "":
+		return segment
+	case strings.HasPrefix(path, "["):
+		return segment + path
+	default:
+		return segment + "." + path
+	}
+}
@@ -0,0 +1,98 @@
+package internal
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+
+	"codeberg.org/yaadata/opt/core"
+)
+
+type warned[T any] struct {
+	result   core.Result[T]
+	warnings []error
+}
+
+// interface guard
+var _ core.Warned[string] = (*warned[string])(nil)
+
+func WithWarnings[T any](result core.Result[T], warnings ...error) core.Warned[T] {
+	return &warned[T]{
+		result:   result,
+		warnings: slices.Clip(slices.DeleteFunc(slices.Clone(warnings), isNilError)),
+	}
+}
+
+func (w *warned[T]) Escalate() core.Result[T] {
+	if !w.HasWarnings() {
+		return w.result
+	}
+	if w.result.IsOk() {
+		return Err[T](errors.Join(w.warnings...))
+	}
+	return w.result.MapErr(func(err error) error {
+		return errors.Join(append([]error{err}, w.warnings...)...)
+	})
+}
+
+func (w *warned[T]) HasWarnings() bool {
+	return len(w.warnings) > 0
+}
+
+func (w *warned[T]) Result() core.Result[T] {
+	return w.result
+}
+
+func (w *warned[T]) Warn(warning error) core.Warned[T] {
+	if warning == nil {
+		return w
+	}
+	return &warned[T]{
+		result:   w.result,
+		warnings: append(slices.Clip(w.warnings), warning),
+	}
+}
+
+func (w *warned[T]) Warnings() []error {
+	return w.warnings
+}
+
+// Format prints the Result with the verb and flags it was called with,
+// followed by the warnings.
+func (w *warned[T]) Format(f fmt.State, verb rune) {
+	fmt.Fprintf(f, fmt.FormatString(f, verb), w.result)
+	if !w.HasWarnings() {
+		return
+	}
+	fmt.Fprint(f, " (warnings: ")
+	for i, warning := range w.warnings {
+		if i > 0 {
+			fmt.Fprint(f, "; ")
+		}
+		fmt.Fprint(f, warning)
+	}
+	fmt.Fprint(f, ")")
+}
+
+// LogValue records the value or error, and the warnings, as a group.
+func (w *warned[T]) LogValue() slog.Value {
+	attrs := make([]slog.Attr, 0, 2)
+	if w.result.IsOk() {
+		attrs = append(attrs, slog.Any("value", w.result.Unwrap()))
+	} else {
+		attrs = append(attrs, slog.String("error", w.result.UnwrapErr().Error()))
+	}
+	if w.HasWarnings() {
+		warnings := make([]string, len(w.warnings))
+		for i, warning := range w.warnings {
+			warnings[i] = warning.Error()
+		}
+		attrs = append(attrs, slog.Any("warnings", warnings))
+	}
+	return slog.GroupValue(attrs...)
+}
+
+func isNilError(err error) bool {
+	return err == nil
+}
@@ -0,0 +1,185 @@
+package optionsgo_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/shoenig/test/must"
+
+	. "codeberg.org/yaadata/opt"
+	"codeberg.org/yaadata/opt/extension"
+)
+
+func TestOptionResult_Found(t *testing.T) {
+	t.Parallel()
+	t.Run("State is Some", func(t *testing.T) {
+		t.Parallel()
+		// [A]rrange
+		found := Found(3)
+		// [A]ct
+		actual := found.IsSome()
+		// [A]ssert
+		must.True(t, actual)
+		must.False(t, found.IsNone())
+		must.False(t, found.IsError())
+		must.True(t, found.Err().IsNone())
+	})
+
+	t.Run("OrNotFound is Ok", func(t *testing.T) {
+		t.Parallel()
+		// [A]rrange
+		found := Found(3)
+		// [A]ct
+		actual := found.OrNotFound(errors.New("not found"))
+		// [A]ssert
+		must.Eq(t, 3, actual.Unwrap())
+		must.Eq(t, 3, found.UnwrapOrDefault())
+	})
+
+	t.Run("Converts to both nested forms", func(t *testing.T) {
+		t.Parallel()
+		// [A]rrange
+		found := Found(3)
+		// [A]ct
+		asResult := found.Result()
+		asOption := found.Option()
+		// [A]ssert
+		must.Eq(t, 3, asResult.Unwrap().Unwrap())
+		must.Eq(t, 3, asOption.Unwrap().Unwrap())
+		must.True(t, extension.OptionResultFromResult(asResult).IsSome())
+		must.True(t, extension.OptionResultFromOption(asOption).IsSome())
+	})
+}
+
+func TestOptionResult_Absent(t *testing.T) {
+	t.Parallel()
+	t.Run("State is None", func(t *testing.T) {
+		t.Parallel()
+		// [A]rrange
+		absent := Absent[int]()
+		// [A]ct
+		actual := absent.IsNone()
+		// [A]ssert
+		must.True(t, actual)
+		must.False(t, absent.IsSome())
+		must.False(t, absent.IsError())
+	})
+
+	t.Run("OrNotFound is Err", func(t *testing.T) {
+		t.Parallel()
+		// [A]rrange
+		errNotFound := errors.New("not found")
+		absent := Absent[int]()
+		// [A]ct
+		actual := absent.OrNotFound(errNotFound)
+		// [A]ssert
+		must.Eq(t, errNotFound, actual.UnwrapErr())
+		must.Eq(t, 0, absent.UnwrapOrDefault())
+	})
+
+	t.Run("Converts to both nested forms", func(t *testing.T) {
+		t.Parallel()
+		// [A]rrange
+		absent := Absent[int]()
+		// [A]ct
+		asResult := absent.Result()
+		asOption := absent.Option()
+		// [A]ssert
+		must.True(t, asResult.Unwrap().IsNone())
+		must.True(t, asOption.IsNone())
+		must.True(t, extension.OptionResultFromResult(asResult).IsNone())
+		must.True(t, extension.OptionResultFromOption(asOption).IsNone())
+	})
+}
+
+func TestOptionResult_Failed(t *testing.T) {
+	t.Parallel()
+	t.Run("State is Err", func(t *testing.T) {
+		t.Parallel()
+		// [A]rrange
+		cause := errors.New("connection refused")
+		failed := Failed[int](cause)
+		// [A]ct
+		actual := failed.IsError()
+		// [A]ssert
+		must.True(t, actual)
+		must.False(t, failed.IsSome())
+		must.False(t, failed.IsNone())
+		must.Eq(t, cause, failed.Err().Unwrap())
+	})
+
+	t.Run("OrNotFound keeps the error", func(t *testing.T) {
+		t.Parallel()
+		// [A]rrange
+		cause := errors.New("connection refused")
+		failed := Failed[int](cause)
+		// [A]ct
+		actual := failed.OrNotFound(errors.New("not found"))
+		// [A]ssert
+		must.Eq(t, cause, actual.UnwrapErr())
+		must.Eq(t, 0, failed.UnwrapOrDefault())
+	})
+
+	t.Run("Converts to both nested forms", func(t *testing.T) {
+		t.Parallel()
+		// [A]rrange
+		cause := errors.New("connection refused")
+		failed := Failed[int](cause)
+		// [A]ct
+		asResult := failed.Result()
+		asOption := failed.Option()
+		// [A]ssert
+		must.Eq(t, cause, asResult.UnwrapErr())
+		must.Eq(t, cause, asOption.Unwrap().UnwrapErr())
+		must.Eq(t, cause, extension.OptionResultFromResult(asResult).Err().Unwrap())
+		must.Eq(t, cause, extension.OptionResultFromOption(asOption).Err().Unwrap())
+	})
+}
+
+type user struct {
+	ID   int
+	Name string
+}
+
+type userRepo struct {
+	users map[int]user
+	down  bool
+}
+
+func (r *userRepo) FindUser(id int) OptionResult[user] {
+	if r.down {
+		return Failed[user](errors.New("connection refused"))
+	}
+	if u, ok := r.users[id]; ok {
+		return Found(u)
+	}
+	return Absent[user]()
+}
+
+func Example_optionResult() {
+	errNotFound := errors.New("user not found")
+	repo := &userRepo{users: map[int]user{1: {ID: 1, Name: "Alice"}}}
+	describe := func(id int) string {
+		name := extension.OptionResultMap(repo.FindUser(id), func(u user) string { return u.Name })
+		switch {
+		case name.IsSome():
+			return "found " + name.UnwrapOrDefault()
+		case name.IsNone():
+			return "absent"
+		default:
+			return "failed: " + name.Err().Unwrap().Error()
+		}
+	}
+
+	fmt.Println(describe(1))
+	fmt.Println(describe(2))
+	repo.down = true
+	fmt.Println(describe(1))
+	fmt.Println(Absent[user]().OrNotFound(errNotFound).UnwrapErr())
+	// Output:
+	// found Alice
+	// absent
+	// failed: connection refused
+	// user not found
+}
@@ -10,6 +10,8 @@ import (
 	"github.com/shoenig/test/must"
 
 	. "codeberg.org/yaadata/opt"
+	"codeberg.org/yaadata/opt/core"
+	"codeberg.org/yaadata/opt/extension"
 )
 
 func TestOption_None(t *testing.T) {
@@ -24,6 +26,17 @@ func TestOption_None(t *testing.T) {
 		must.False(t, actual)
 	})
 
+	t.Run("Kind is KindNone", func(t *testing.T) {
+		t.Parallel()
+		// [A]rrange
+		val := None[string]()
+		// [A]ct
+		actual := val.Kind()
+		// [A]ssert
+		must.Eq(t, core.KindNone, actual)
+		must.Eq(t, "None", actual.String())
+	})
+
 	t.Run("IsSomeAnd is false", func(t *testing.T) {
 		t.Parallel()
 		// [A]rrange
@@ -88,7 +101,9 @@ func TestOption_None(t *testing.T) {
 		msg := "test panic"
 		defer func() {
 			if r := recover(); r != nil {
-				must.Eq(t, msg, r.(string))
+				err := extension.MustCast[error](r)
+				must.EqError(t, err, msg)
+				must.ErrorIs(t, err, core.ErrUnwrapNone)
 			} else {
 				t.Error("expected a panic but none occurred")
 			}
@@ -98,6 +113,22 @@ func TestOption_None(t *testing.T) {
 		val.Expect(msg)
 	})
 
+	t.Run("Unwrap panics with an UnwrapError", func(t *testing.T) {
+		t.Parallel()
+		// [A]rrange
+		val := None[string]()
+		// [A]ct
+		actual := extension.Catch(func() string {
+			return val.Unwrap()
+		})
+		// [A]ssert
+		var unwrapErr *core.UnwrapError
+		must.True(t, errors.As(actual.UnwrapErr(), &unwrapErr))
+		must.Eq(t, core.UnwrapKindNone, unwrapErr.Kind)
+		must.Nil(t, unwrapErr.Err)
+		must.StrHasSuffix(t, "option_test.go", unwrapErr.Caller.File)
+	})
+
 	t.Run("Inspect should call fn on none", func(t *testing.T) {
 		t.Parallel()
 		// [A]rrange
@@ -116,7 +147,9 @@ func TestOption_None(t *testing.T) {
 		// [A]rrange
 		defer func() {
 			if r := recover(); r != nil {
-				must.Eq(t, "failed to unwrap None value", r.(string))
+				err := extension.MustCast[error](r)
+				must.EqError(t, err, "failed to unwrap None value")
+				must.ErrorIs(t, err, core.ErrUnwrapNone)
 			} else {
 				t.Error("expected a panic but none occurred")
 			}
@@ -126,6 +159,27 @@ func TestOption_None(t *testing.T) {
 		val.Unwrap()
 	})
 
+	t.Run("Get returns zero value and false", func(t *testing.T) {
+		t.Parallel()
+		// [A]rrange
+		opt := None[string]()
+		// [A]ct
+		value, ok := opt.Get()
+		// [A]ssert
+		must.False(t, ok)
+		must.Eq(t, "", value)
+	})
+
+	t.Run("ToPointer returns nil", func(t *testing.T) {
+		t.Parallel()
+		// [A]rrange
+		opt := None[string]()
+		// [A]ct
+		actual := opt.ToPointer()
+		// [A]ssert
+		must.Nil(t, actual)
+	})
+
 	t.Run("UnwrapOrElse returns Else", func(t *testing.T) {
 		t.Parallel()
 		// [A]rrange
@@ -335,6 +389,17 @@ func TestOption_Some(t *testing.T) {
 		must.True(t, actual)
 	})
 
+	t.Run("Kind is KindSome", func(t *testing.T) {
+		t.Parallel()
+		// [A]rrange
+		opt := Some("SOME")
+		// [A]ct
+		actual := opt.Kind()
+		// [A]ssert
+		must.Eq(t, core.KindSome, actual)
+		must.Eq(t, "Some", actual.String())
+	})
+
 	t.Run("IsSomeAnd with predicate leading to true", func(t *testing.T) {
 		t.Parallel()
 		// [A]rrange
@@ -480,6 +545,29 @@ func TestOption_Some(t *testing.T) {
 		must.Eq(t, EXPECTED, actual)
 	})
 
+	t.Run("Get returns the value and true", func(t *testing.T) {
+		t.Parallel()
+		// [A]rrange
+		opt := Some("SOME")
+		// [A]ct
+		value, ok := opt.Get()
+		// [A]ssert
+		must.True(t, ok)
+		must.Eq(t, "SOME", value)
+	})
+
+	t.Run("ToPointer returns a copy", func(t *testing.T) {
+		t.Parallel()
+		// [A]rrange
+		opt := Some("SOME")
+		// [A]ct
+		actual := opt.ToPointer()
+		*actual = "CHANGED"
+		// [A]ssert
+		must.Eq(t, "SOME", opt.Unwrap())
+		must.Eq(t, "SOME", *opt.ToPointer())
+	})
+
 	t.Run("UnwrapOrElse returns some value", func(t *testing.T) {
 		t.Parallel()
 		// [A]rrange
@@ -0,0 +1,137 @@
+package optionsgo_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/shoenig/test/must"
+
+	. "codeberg.org/yaadata/opt"
+)
+
+func TestOutcome_Success(t *testing.T) {
+	t.Parallel()
+	t.Run("IsSuccess is true", func(t *testing.T) {
+		t.Parallel()
+		// [A]rrange
+		outcome := Success()
+		// [A]ct
+		actual := outcome.IsSuccess()
+		// [A]ssert
+		must.True(t, actual)
+		must.False(t, outcome.IsFailure())
+		must.NoError(t, outcome.Error())
+	})
+
+	t.Run("AndThen calls fn", func(t *testing.T) {
+		t.Parallel()
+		// [A]rrange
+		expected := errors.New("seed failed")
+		outcome := Success()
+		// [A]ct
+		actual := outcome.AndThen(func() Outcome {
+			return Failure(expected)
+		})
+		// [A]ssert
+		must.Eq(t, expected, actual.Error())
+	})
+
+	t.Run("Error handlers are not called", func(t *testing.T) {
+		t.Parallel()
+		// [A]rrange
+		called := false
+		outcome := Success()
+		// [A]ct
+		actual := outcome.
+			InspectErr(func(err error) { called = true }).
+			MapErr(func(err error) error { called = true; return err }).
+			OrElse(func(err error) Outcome { called = true; return Success() })
+		// [A]ssert
+		must.False(t, called)
+		must.True(t, actual.IsSuccess())
+	})
+
+	t.Run("Formats as Success", func(t *testing.T) {
+		t.Parallel()
+		// [A]ct
+		actual := fmt.Sprintf("%v", Success())
+		// [A]ssert
+		must.Eq(t, "Success", actual)
+	})
+
+	t.Run("Failure of nil is Success", func(t *testing.T) {
+		t.Parallel()
+		// [A]ct
+		actual := Failure(nil)
+		// [A]ssert
+		must.True(t, actual.IsSuccess())
+	})
+}
+
+func TestOutcome_Failure(t *testing.T) {
+	t.Parallel()
+	t.Run("IsFailure is true", func(t *testing.T) {
+		t.Parallel()
+		// [A]rrange
+		cause := errors.New("disk full")
+		outcome := Failure(cause)
+		// [A]ct
+		actual := outcome.IsFailure()
+		// [A]ssert
+		must.True(t, actual)
+		must.False(t, outcome.IsSuccess())
+		must.Eq(t, cause, outcome.Error())
+	})
+
+	t.Run("AndThen skips fn", func(t *testing.T) {
+		t.Parallel()
+		// [A]rrange
+		called := false
+		outcome := Failure(errors.New("disk full"))
+		// [A]ct
+		actual := outcome.AndThen(func() Outcome {
+			called = true
+			return Success()
+		})
+		// [A]ssert
+		must.False(t, called)
+		must.True(t, actual.IsFailure())
+	})
+
+	t.Run("InspectErr and MapErr see the error", func(t *testing.T) {
+		t.Parallel()
+		// [A]rrange
+		var inspected error
+		cause := errors.New("disk full")
+		outcome := Failure(cause)
+		// [A]ct
+		actual := outcome.
+			InspectErr(func(err error) { inspected = err }).
+			MapErr(func(err error) error { return fmt.Errorf("saving: %w", err) })
+		// [A]ssert
+		must.Eq(t, cause, inspected)
+		must.EqError(t, actual.Error(), "saving: disk full")
+		must.ErrorIs(t, actual.Error(), cause)
+	})
+
+	t.Run("OrElse recovers", func(t *testing.T) {
+		t.Parallel()
+		// [A]rrange
+		outcome := Failure(errors.New("primary down"))
+		// [A]ct
+		actual := outcome.OrElse(func(err error) Outcome {
+			return Success()
+		})
+		// [A]ssert
+		must.True(t, actual.IsSuccess())
+	})
+
+	t.Run("Formats as Failure", func(t *testing.T) {
+		t.Parallel()
+		// [A]ct
+		actual := fmt.Sprintf("%v", Failure(errors.New("disk full")))
+		// [A]ssert
+		must.Eq(t, "Failure(disk full)", actual)
+	})
+}
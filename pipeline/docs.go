@@ -0,0 +1,8 @@
+// Package pipeline connects Result-returning stages with channels.
+//
+// Each stage runs a configurable number of workers. Ok values flow on to the
+// next stage; Err values are tagged with the stage name and input and either
+// delivered in the output sequence or handed to a dead-letter handler.
+// Stages preserve input order unless configured otherwise, and bounded
+// channels between stages apply backpressure to the source.
+package pipeline
@@ -0,0 +1,21 @@
+package pipeline
+
+import "fmt"
+
+// StageError is a failure reported by a stage.
+type StageError struct {
+	// Stage is the name of the stage that returned Err.
+	Stage string
+	// Input is the value the stage was processing.
+	Input any
+	// Err is the error the stage returned.
+	Err error
+}
+
+func (e *StageError) Error() string {
+	return fmt.Sprintf("stage %q failed on input %v: %v", e.Stage, e.Input, e.Err)
+}
+
+func (e *StageError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,105 @@
+package pipeline
+
+import (
+	"context"
+	"iter"
+	"sync"
+
+	"codeberg.org/yaadata/opt/core"
+	"codeberg.org/yaadata/opt/internal"
+)
+
+// Flow is a lazily started pipeline whose last stage produces values of type T.
+// Nothing runs until the sequence returned by Results is iterated.
+type Flow[T any] struct {
+	cfg   *config
+	start func(ctx context.Context, wg *sync.WaitGroup) <-chan envelope[T]
+}
+
+// Option configures a pipeline.
+type Option func(*config)
+
+type config struct {
+	deadLetter func(*StageError)
+}
+
+// envelope carries either a value or the failure that replaced it.
+type envelope[T any] struct {
+	value T
+	err   *StageError
+}
+
+// WithDeadLetter routes every stage failure to handler instead of the output
+// sequence. The handler is called from worker goroutines and must be safe for
+// concurrent use.
+func WithDeadLetter(handler func(*StageError)) Option {
+	return func(c *config) {
+		c.deadLetter = handler
+	}
+}
+
+// From creates a Flow whose source is src.
+//
+// Example:
+//
+//	parsed := Then(From(slices.Values(lines)), "parse", parseLine, Workers(4))
+//	stored := Then(parsed, "store", storeRecord, Workers(2))
+//	for res := range stored.Results(ctx) {
+//	    ...
+//	}
+func From[T any](src iter.Seq[T], opts ...Option) *Flow[T] {
+	cfg := &config{}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	return &Flow[T]{
+		cfg: cfg,
+		start: func(ctx context.Context, wg *sync.WaitGroup) <-chan envelope[T] {
+			out := make(chan envelope[T])
+			wg.Go(func() {
+				defer close(out)
+				for value := range src {
+					select {
+					case out <- envelope[T]{value: value}:
+					case <-ctx.Done():
+						return
+					}
+				}
+			})
+			return out
+		},
+	}
+}
+
+// Results starts the pipeline and yields the output of the last stage.
+//
+// Each Ok value yields Ok. Without a dead-letter handler, each stage failure
+// yields Err(*StageError) in the position of the input that failed. If ctx
+// ends before the pipeline drains, the final element is Err(ctx.Err()).
+//
+// Breaking out of the loop early stops the pipeline. Every goroutine the
+// pipeline started has exited by the time iteration returns.
+func (f *Flow[T]) Results(ctx context.Context) iter.Seq[core.Result[T]] {
+	return func(yield func(core.Result[T]) bool) {
+		runCtx, cancel := context.WithCancel(ctx)
+		var wg sync.WaitGroup
+		defer func() {
+			cancel()
+			wg.Wait()
+		}()
+		for env := range f.start(runCtx, &wg) {
+			var res core.Result[T]
+			if env.err != nil {
+				res = internal.Err[T](env.err)
+			} else {
+				res = internal.Ok(env.value)
+			}
+			if !yield(res) {
+				return
+			}
+		}
+		if err := ctx.Err(); err != nil {
+			yield(internal.Err[T](err))
+		}
+	}
+}
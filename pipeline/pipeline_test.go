@@ -0,0 +1,249 @@
+package pipeline_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"iter"
+	"slices"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"testing/synctest"
+	"time"
+
+	"github.com/shoenig/test/must"
+
+	"codeberg.org/yaadata/opt/core"
+	"codeberg.org/yaadata/opt/internal"
+	"codeberg.org/yaadata/opt/pipeline"
+)
+
+var errOdd = errors.New("odd")
+
+func double(_ context.Context, value int) core.Result[int] {
+	return internal.Ok(value * 2)
+}
+
+func evenOnly(_ context.Context, value int) core.Result[int] {
+	if value%2 != 0 {
+		return internal.Err[int](errOdd)
+	}
+	return internal.Ok(value)
+}
+
+// jitter sleeps longer for smaller values so that workers finish out of order.
+func jitter(_ context.Context, value int) core.Result[int] {
+	time.Sleep(time.Duration(10-value) * time.Millisecond)
+	return internal.Ok(value)
+}
+
+func collect[T any](flow *pipeline.Flow[T], ctx context.Context) []core.Result[T] {
+	var results []core.Result[T]
+	for res := range flow.Results(ctx) {
+		results = append(results, res)
+	}
+	return results
+}
+
+func values[T any](results []core.Result[T]) []T {
+	var out []T
+	for _, res := range results {
+		out = append(out, res.Unwrap())
+	}
+	return out
+}
+
+func TestFlow_Results(t *testing.T) {
+	t.Parallel()
+	t.Run("Ok values flow through every stage", func(t *testing.T) {
+		t.Parallel()
+		// [A]rrange
+		doubled := pipeline.Then(pipeline.From(slices.Values([]int{1, 2, 3})), "double", double)
+		formatted := pipeline.Then(doubled, "format", func(_ context.Context, value int) core.Result[string] {
+			return internal.Ok(strconv.Itoa(value))
+		})
+		// [A]ct
+		actual := collect(formatted, context.Background())
+		// [A]ssert
+		must.Eq(t, []string{"2", "4", "6"}, values(actual))
+	})
+
+	t.Run("Failures are tagged with stage and input", func(t *testing.T) {
+		t.Parallel()
+		// [A]rrange
+		filtered := pipeline.Then(pipeline.From(slices.Values([]int{1, 2, 3})), "even", evenOnly)
+		doubled := pipeline.Then(filtered, "double", double)
+		// [A]ct
+		actual := collect(doubled, context.Background())
+		// [A]ssert
+		must.SliceLen(t, 3, actual)
+		var stageErr *pipeline.StageError
+		must.True(t, errors.As(actual[0].UnwrapErr(), &stageErr))
+		must.Eq(t, "even", stageErr.Stage)
+		must.Eq(t, any(1), stageErr.Input)
+		must.ErrorIs(t, stageErr, errOdd)
+		must.Eq(t, 4, actual[1].Unwrap())
+		must.EqError(t, actual[2].UnwrapErr(), `stage "even" failed on input 3: odd`)
+	})
+
+	t.Run("Dead-letter handler receives failures instead of the output", func(t *testing.T) {
+		t.Parallel()
+		// [A]rrange
+		var mu sync.Mutex
+		var dead []string
+		handler := func(err *pipeline.StageError) {
+			mu.Lock()
+			defer mu.Unlock()
+			dead = append(dead, fmt.Sprintf("%s:%v", err.Stage, err.Input))
+		}
+		source := pipeline.From(slices.Values([]int{1, 2, 3, 4}), pipeline.WithDeadLetter(handler))
+		filtered := pipeline.Then(source, "even", evenOnly, pipeline.Workers(2))
+		// [A]ct
+		actual := collect(filtered, context.Background())
+		// [A]ssert
+		must.Eq(t, []int{2, 4}, values(actual))
+		must.SliceContainsAll(t, []string{"even:1", "even:3"}, dead)
+	})
+}
+
+func TestFlow_Ordering(t *testing.T) {
+	t.Parallel()
+	t.Run("Stages preserve input order across workers", func(t *testing.T) {
+		t.Parallel()
+		synctest.Test(t, func(t *testing.T) {
+			// [A]rrange
+			input := []int{1, 2, 3, 4, 5, 6, 7, 8, 9}
+			flow := pipeline.Then(pipeline.From(slices.Values(input)), "jitter", jitter, pipeline.Workers(4))
+			// [A]ct
+			actual := collect(flow, context.Background())
+			// [A]ssert
+			must.Eq(t, input, values(actual))
+		})
+	})
+
+	t.Run("Ordered stage runs its workers concurrently", func(t *testing.T) {
+		t.Parallel()
+		synctest.Test(t, func(t *testing.T) {
+			// [A]rrange
+			slow := func(_ context.Context, value int) core.Result[int] {
+				time.Sleep(time.Second)
+				return internal.Ok(value)
+			}
+			flow := pipeline.Then(pipeline.From(slices.Values([]int{1, 2, 3, 4})), "slow", slow, pipeline.Workers(4))
+			start := time.Now()
+			// [A]ct
+			collect(flow, context.Background())
+			// [A]ssert
+			must.Eq(t, time.Second, time.Since(start))
+		})
+	})
+
+	t.Run("Unordered stage emits in completion order", func(t *testing.T) {
+		t.Parallel()
+		synctest.Test(t, func(t *testing.T) {
+			// [A]rrange
+			input := []int{7, 8, 9}
+			flow := pipeline.Then(pipeline.From(slices.Values(input)), "jitter", jitter,
+				pipeline.Workers(3), pipeline.Unordered())
+			// [A]ct
+			actual := collect(flow, context.Background())
+			// [A]ssert
+			must.Eq(t, []int{9, 8, 7}, values(actual))
+		})
+	})
+}
+
+func TestFlow_Backpressure(t *testing.T) {
+	t.Parallel()
+	t.Run("Slow consumer bounds how much of the source is read", func(t *testing.T) {
+		t.Parallel()
+		synctest.Test(t, func(t *testing.T) {
+			// [A]rrange
+			var pulled atomic.Int32
+			source := func(yield func(int) bool) {
+				for i := range 1000 {
+					pulled.Add(1)
+					if !yield(i) {
+						return
+					}
+				}
+			}
+			flow := pipeline.Then(pipeline.From(source), "double", double, pipeline.Workers(2), pipeline.Buffer(3))
+			next, stop := iterPull(flow.Results(context.Background()))
+			defer stop()
+			// [A]ct
+			first := next()
+			synctest.Wait()
+			// [A]ssert
+			must.Eq(t, 0, first.Unwrap())
+			// 1 yielded, 3 buffered, 1 held by the collector, 2 finished by the workers,
+			// 1 held by the dispatcher and 1 held by the source
+			must.LessEq(t, int32(9), pulled.Load())
+		})
+	})
+
+	t.Run("Breaking early stops every goroutine", func(t *testing.T) {
+		t.Parallel()
+		synctest.Test(t, func(t *testing.T) {
+			// [A]rrange
+			endless := func(yield func(int) bool) {
+				for i := 0; ; i++ {
+					if !yield(i) {
+						return
+					}
+				}
+			}
+			flow := pipeline.Then(pipeline.From(endless), "double", double, pipeline.Workers(4))
+			// [A]ct
+			var actual []int
+			for res := range flow.Results(context.Background()) {
+				actual = append(actual, res.Unwrap())
+				if len(actual) == 3 {
+					break
+				}
+			}
+			// [A]ssert
+			must.Eq(t, []int{0, 2, 4}, actual)
+			// synctest.Test fails if any goroutine is still running when it returns
+		})
+	})
+
+	t.Run("Cancelled context ends the output with the context error", func(t *testing.T) {
+		t.Parallel()
+		synctest.Test(t, func(t *testing.T) {
+			// [A]rrange
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			endless := func(yield func(int) bool) {
+				for i := 0; ; i++ {
+					if !yield(i) {
+						return
+					}
+				}
+			}
+			flow := pipeline.Then(pipeline.From(endless), "double", double, pipeline.Workers(2))
+			// [A]ct
+			var last core.Result[int]
+			count := 0
+			for res := range flow.Results(ctx) {
+				count++
+				if count == 5 {
+					cancel()
+				}
+				last = res
+			}
+			// [A]ssert
+			must.ErrorIs(t, last.UnwrapErr(), context.Canceled)
+		})
+	})
+}
+
+func iterPull[T any](seq iter.Seq[T]) (func() T, func()) {
+	next, stop := iter.Pull(seq)
+	return func() T {
+		value, _ := next()
+		return value
+	}, stop
+}
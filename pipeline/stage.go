@@ -0,0 +1,195 @@
+package pipeline
+
+import (
+	"context"
+	"sync"
+
+	"codeberg.org/yaadata/opt/core"
+	"codeberg.org/yaadata/opt/internal"
+)
+
+// StageOption configures a single stage.
+type StageOption func(*stageConfig)
+
+type stageConfig struct {
+	workers   int
+	buffer    int
+	unordered bool
+}
+
+// Workers runs the stage on n concurrent workers. Defaults to 1.
+// Panics if n is less than one.
+func Workers(n int) StageOption {
+	if n < 1 {
+		panic("pipeline: a stage needs at least one worker")
+	}
+	return func(c *stageConfig) {
+		c.workers = n
+	}
+}
+
+// Buffer lets up to n finished values wait for the next stage before the
+// stage stops taking input. Defaults to 0.
+func Buffer(n int) StageOption {
+	return func(c *stageConfig) {
+		c.buffer = n
+	}
+}
+
+// Unordered emits values in the order the workers finish them rather than in
+// input order, trading ordering for throughput when work times vary.
+func Unordered() StageOption {
+	return func(c *stageConfig) {
+		c.unordered = true
+	}
+}
+
+// Then appends a stage that applies fn to every value flowing out of f.
+// The name tags the failures the stage reports.
+//
+// Example:
+//
+//	enriched := Then(users, "enrich", func(ctx context.Context, u User) core.Result[Profile] {
+//	    return profiles.Fetch(ctx, u.ID)
+//	}, Workers(8), Buffer(16))
+func Then[T, V any](
+	f *Flow[T],
+	name string,
+	fn func(ctx context.Context, value T) core.Result[V],
+	opts ...StageOption,
+) *Flow[V] {
+	cfg := stageConfig{workers: 1}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	s := &stage[T, V]{name: name, fn: fn, cfg: cfg, deadLetter: f.cfg.deadLetter}
+	return &Flow[V]{
+		cfg: f.cfg,
+		start: func(ctx context.Context, wg *sync.WaitGroup) <-chan envelope[V] {
+			return s.run(ctx, wg, f.start(ctx, wg))
+		},
+	}
+}
+
+type stage[T, V any] struct {
+	name       string
+	fn         func(ctx context.Context, value T) core.Result[V]
+	cfg        stageConfig
+	deadLetter func(*StageError)
+}
+
+// process applies the stage to env. It returns false when the failure was
+// handed to the dead-letter handler and nothing should flow downstream.
+func (s *stage[T, V]) process(ctx context.Context, env envelope[T]) (envelope[V], bool) {
+	if env.err != nil {
+		return envelope[V]{err: env.err}, true
+	}
+	res := s.fn(ctx, env.value)
+	if res.IsOk() {
+		return envelope[V]{value: res.Unwrap()}, true
+	}
+	stageErr := &StageError{Stage: s.name, Input: env.value, Err: res.UnwrapErr()}
+	if s.deadLetter != nil {
+		s.deadLetter(stageErr)
+		return envelope[V]{}, false
+	}
+	return envelope[V]{err: stageErr}, true
+}
+
+func (s *stage[T, V]) run(ctx context.Context, wg *sync.WaitGroup, in <-chan envelope[T]) <-chan envelope[V] {
+	out := make(chan envelope[V], s.cfg.buffer)
+	if s.cfg.unordered {
+		s.runUnordered(ctx, wg, in, out)
+	} else {
+		s.runOrdered(ctx, wg, in, out)
+	}
+	return out
+}
+
+func (s *stage[T, V]) runUnordered(
+	ctx context.Context,
+	wg *sync.WaitGroup,
+	in <-chan envelope[T],
+	out chan<- envelope[V],
+) {
+	var workers sync.WaitGroup
+	for range s.cfg.workers {
+		workers.Go(func() {
+			for env := range in {
+				next, forward := s.process(ctx, env)
+				if !forward {
+					continue
+				}
+				select {
+				case out <- next:
+				case <-ctx.Done():
+					return
+				}
+			}
+		})
+	}
+	wg.Go(func() {
+		workers.Wait()
+		close(out)
+	})
+}
+
+// runOrdered hands each input to a worker together with a single-use channel
+// for its output, and queues those channels in input order. The collector
+// drains the queue front to back, so outputs leave in input order while up
+// to cfg.workers inputs are processed at once.
+func (s *stage[T, V]) runOrdered(
+	ctx context.Context,
+	wg *sync.WaitGroup,
+	in <-chan envelope[T],
+	out chan<- envelope[V],
+) {
+	type promise = chan core.Option[envelope[V]]
+	queue := make(chan promise, s.cfg.workers)
+	slots := make(chan struct{}, s.cfg.workers)
+	wg.Go(func() {
+		defer close(queue)
+		for env := range in {
+			select {
+			case slots <- struct{}{}:
+			case <-ctx.Done():
+				return
+			}
+			p := make(promise, 1)
+			select {
+			case queue <- p:
+			case <-ctx.Done():
+				<-slots
+				return
+			}
+			wg.Go(func() {
+				defer func() { <-slots }()
+				next, forward := s.process(ctx, env)
+				if forward {
+					p <- internal.Some(next)
+				} else {
+					p <- internal.None[envelope[V]]()
+				}
+			})
+		}
+	})
+	wg.Go(func() {
+		defer close(out)
+		for p := range queue {
+			var next core.Option[envelope[V]]
+			select {
+			case next = <-p:
+			case <-ctx.Done():
+				return
+			}
+			if next.IsNone() {
+				continue
+			}
+			select {
+			case out <- next.Unwrap():
+			case <-ctx.Done():
+				return
+			}
+		}
+	})
+}
@@ -0,0 +1,90 @@
+package resilience
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"codeberg.org/yaadata/opt/core"
+	"codeberg.org/yaadata/opt/internal"
+)
+
+// Bulkhead is a Guard that bounds the number of calls running concurrently.
+type Bulkhead struct {
+	slots chan struct{}
+	mode  Mode
+
+	mu       sync.Mutex
+	meanHold time.Duration
+}
+
+// interface guard
+var _ Guard = (*Bulkhead)(nil)
+
+// NewBulkhead creates a Bulkhead admitting up to limit concurrent calls.
+// Panics if limit is less than one.
+//
+// Example:
+//
+//	bulkhead := NewBulkhead(8, FailFast)
+//	res := Do(ctx, bulkhead, callDownstream)
+func NewBulkhead(limit int, mode Mode) *Bulkhead {
+	if limit < 1 {
+		panic("resilience: bulkhead limit must be at least one")
+	}
+	return &Bulkhead{
+		slots: make(chan struct{}, limit),
+		mode:  mode,
+	}
+}
+
+// Acquire takes a slot.
+//
+// In Wait mode, Acquire blocks until a slot frees up or ctx ends, in which case ctx.Err() is the Err.
+//
+// In FailFast mode, Acquire returns Err(*ErrBulkheadFull) when every slot is taken.
+func (b *Bulkhead) Acquire(ctx context.Context) core.Result[Release] {
+	if err := ctx.Err(); err != nil {
+		return internal.Err[Release](err)
+	}
+	if b.mode == FailFast {
+		select {
+		case b.slots <- struct{}{}:
+			return internal.Ok(b.release(time.Now()))
+		default:
+			b.mu.Lock()
+			retryAfter := b.meanHold
+			b.mu.Unlock()
+			return internal.Err[Release](&ErrBulkheadFull{Limit: cap(b.slots), RetryAfter: retryAfter})
+		}
+	}
+	select {
+	case b.slots <- struct{}{}:
+		return internal.Ok(b.release(time.Now()))
+	case <-ctx.Done():
+		return internal.Err[Release](ctx.Err())
+	}
+}
+
+// InFlight returns the number of slots currently taken.
+func (b *Bulkhead) InFlight() int {
+	return len(b.slots)
+}
+
+func (b *Bulkhead) release(acquired time.Time) Release {
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			held := time.Since(acquired)
+			b.mu.Lock()
+			// exponentially weighted so the estimate follows recent behaviour
+			if b.meanHold == 0 {
+				b.meanHold = held
+			} else {
+				b.meanHold = (b.meanHold*7 + held) / 8
+			}
+			b.mu.Unlock()
+			<-b.slots
+		})
+	}
+}
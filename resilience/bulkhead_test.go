@@ -0,0 +1,107 @@
+package resilience_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"testing/synctest"
+	"time"
+
+	"github.com/shoenig/test/must"
+
+	"codeberg.org/yaadata/opt/resilience"
+)
+
+func TestBulkhead(t *testing.T) {
+	t.Parallel()
+	t.Run("FailFast rejects when every slot is taken", func(t *testing.T) {
+		t.Parallel()
+		// [A]rrange
+		bulkhead := resilience.NewBulkhead(1, resilience.FailFast)
+		ctx := context.Background()
+		held := bulkhead.Acquire(ctx)
+		// [A]ct
+		actual := bulkhead.Acquire(ctx)
+		// [A]ssert
+		must.True(t, held.IsOk())
+		must.True(t, actual.IsError())
+		var full *resilience.ErrBulkheadFull
+		must.True(t, errors.As(actual.UnwrapErr(), &full))
+		must.Eq(t, 1, full.Limit)
+		must.Eq(t, 1, bulkhead.InFlight())
+	})
+
+	t.Run("FailFast reports how long slots are usually held", func(t *testing.T) {
+		t.Parallel()
+		synctest.Test(t, func(t *testing.T) {
+			// [A]rrange
+			bulkhead := resilience.NewBulkhead(1, resilience.FailFast)
+			ctx := context.Background()
+			release := bulkhead.Acquire(ctx).Unwrap()
+			time.Sleep(50 * time.Millisecond)
+			release()
+			must.True(t, bulkhead.Acquire(ctx).IsOk())
+			// [A]ct
+			actual := bulkhead.Acquire(ctx)
+			// [A]ssert
+			var full *resilience.ErrBulkheadFull
+			must.True(t, errors.As(actual.UnwrapErr(), &full))
+			must.Eq(t, 50*time.Millisecond, full.RetryAfter)
+		})
+	})
+
+	t.Run("Release is idempotent", func(t *testing.T) {
+		t.Parallel()
+		// [A]rrange
+		bulkhead := resilience.NewBulkhead(2, resilience.FailFast)
+		ctx := context.Background()
+		release := bulkhead.Acquire(ctx).Unwrap()
+		must.True(t, bulkhead.Acquire(ctx).IsOk())
+		// [A]ct
+		release()
+		release()
+		// [A]ssert
+		must.Eq(t, 1, bulkhead.InFlight())
+	})
+
+	t.Run("Wait blocks until a slot is released", func(t *testing.T) {
+		t.Parallel()
+		synctest.Test(t, func(t *testing.T) {
+			// [A]rrange
+			bulkhead := resilience.NewBulkhead(1, resilience.Wait)
+			ctx := context.Background()
+			release := bulkhead.Acquire(ctx).Unwrap()
+			admitted := make(chan struct{})
+			go func() {
+				bulkhead.Acquire(ctx).Unwrap()()
+				close(admitted)
+			}()
+			synctest.Wait()
+			select {
+			case <-admitted:
+				t.Fatal("admitted while the bulkhead was full")
+			default:
+			}
+			// [A]ct
+			release()
+			// [A]ssert
+			<-admitted
+			must.Eq(t, 0, bulkhead.InFlight())
+		})
+	})
+
+	t.Run("Wait returns the context error when ctx ends first", func(t *testing.T) {
+		t.Parallel()
+		synctest.Test(t, func(t *testing.T) {
+			// [A]rrange
+			bulkhead := resilience.NewBulkhead(1, resilience.Wait)
+			must.True(t, bulkhead.Acquire(context.Background()).IsOk())
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+			defer cancel()
+			// [A]ct
+			actual := bulkhead.Acquire(ctx)
+			// [A]ssert
+			must.ErrorIs(t, actual.UnwrapErr(), context.DeadlineExceeded)
+		})
+	})
+}
@@ -0,0 +1,6 @@
+// Package resilience provides guards that bound how often and how
+// concurrently Result-returning producers are invoked.
+//
+// A guard either waits until the call is allowed or, in fail-fast mode,
+// rejects the call immediately with an Err describing when to retry.
+package resilience
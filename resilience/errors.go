@@ -0,0 +1,29 @@
+package resilience
+
+import (
+	"fmt"
+	"time"
+)
+
+// ErrRateLimited is returned by a fail-fast RateLimiter when no token is available.
+type ErrRateLimited struct {
+	// RetryAfter is how long until the next token becomes available.
+	RetryAfter time.Duration
+}
+
+// ErrBulkheadFull is returned by a fail-fast Bulkhead when every slot is in use.
+type ErrBulkheadFull struct {
+	// Limit is the number of concurrent calls the bulkhead admits.
+	Limit int
+	// RetryAfter estimates when a slot frees up, based on how long recent
+	// calls held their slot. It is zero until a call has completed.
+	RetryAfter time.Duration
+}
+
+func (e *ErrRateLimited) Error() string {
+	return fmt.Sprintf("rate limited: retry after %s", e.RetryAfter)
+}
+
+func (e *ErrBulkheadFull) Error() string {
+	return fmt.Sprintf("bulkhead full: %d concurrent calls in flight, retry after %s", e.Limit, e.RetryAfter)
+}
@@ -0,0 +1,63 @@
+package resilience
+
+import (
+	"context"
+
+	"codeberg.org/yaadata/opt/core"
+	"codeberg.org/yaadata/opt/internal"
+)
+
+const (
+	// Wait blocks the caller until the guard admits it or the context ends.
+	Wait Mode = iota
+	// FailFast rejects the caller immediately when the guard cannot admit it.
+	FailFast
+)
+
+// Mode controls how a guard behaves when it cannot admit a caller right away.
+type Mode int
+
+// Release hands a permit back to the guard that issued it.
+type Release func()
+
+// Guard admits or rejects callers before a producer runs.
+type Guard interface {
+	// Acquire returns Ok(release) once the caller is admitted. The release
+	// function must be called exactly once after the guarded work completes.
+	// Returns Err when the guard rejects the caller or the context ends first.
+	Acquire(ctx context.Context) core.Result[Release]
+}
+
+// Do runs fn through the guard.
+// If the guard rejects the call, fn is not invoked and the rejection is returned as Err.
+//
+// Example:
+//
+//	limiter := NewRateLimiter(100*time.Millisecond, 1, FailFast)
+//	res := Do(ctx, limiter, fetchUser)
+//	var limited *ErrRateLimited
+//	if res.IsErrorAnd(func(err error) bool { return errors.As(err, &limited) }) {
+//	    time.Sleep(limited.RetryAfter)
+//	}
+func Do[T any](ctx context.Context, guard Guard, fn func(context.Context) core.Result[T]) core.Result[T] {
+	permit := guard.Acquire(ctx)
+	if permit.IsError() {
+		return internal.ErrFrom[T](permit)
+	}
+	release := permit.Unwrap()
+	defer release()
+	return fn(ctx)
+}
+
+// Wrap returns fn guarded by guard. Guards compose by wrapping repeatedly;
+// the outermost guard is consulted first.
+//
+// Example:
+//
+//	fetch := Wrap(limiter, Wrap(bulkhead, fetchUser))
+//	res := fetch(ctx)
+func Wrap[T any](guard Guard, fn func(context.Context) core.Result[T]) func(context.Context) core.Result[T] {
+	return func(ctx context.Context) core.Result[T] {
+		return Do(ctx, guard, fn)
+	}
+}
@@ -0,0 +1,80 @@
+package resilience_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/shoenig/test/must"
+
+	"codeberg.org/yaadata/opt/core"
+	"codeberg.org/yaadata/opt/internal"
+	"codeberg.org/yaadata/opt/resilience"
+)
+
+func TestWrap(t *testing.T) {
+	t.Parallel()
+	t.Run("Admitted call runs the producer", func(t *testing.T) {
+		t.Parallel()
+		// [A]rrange
+		bulkhead := resilience.NewBulkhead(1, resilience.FailFast)
+		fn := resilience.Wrap(bulkhead, func(_ context.Context) core.Result[string] {
+			return internal.Ok("EXPECTED")
+		})
+		// [A]ct
+		actual := fn(context.Background())
+		// [A]ssert
+		must.Eq(t, "EXPECTED", actual.Unwrap())
+		must.Eq(t, 0, bulkhead.InFlight())
+	})
+
+	t.Run("Rejected call skips the producer", func(t *testing.T) {
+		t.Parallel()
+		// [A]rrange
+		bulkhead := resilience.NewBulkhead(1, resilience.FailFast)
+		must.True(t, bulkhead.Acquire(context.Background()).IsOk())
+		called := false
+		fn := resilience.Wrap(bulkhead, func(_ context.Context) core.Result[string] {
+			called = true
+			return internal.Ok("UNEXPECTED")
+		})
+		// [A]ct
+		actual := fn(context.Background())
+		// [A]ssert
+		must.False(t, called)
+		var full *resilience.ErrBulkheadFull
+		must.True(t, errors.As(actual.UnwrapErr(), &full))
+	})
+
+	t.Run("Guards compose outermost first", func(t *testing.T) {
+		t.Parallel()
+		// [A]rrange
+		limiter := resilience.NewRateLimiter(1<<40, 1, resilience.FailFast)
+		bulkhead := resilience.NewBulkhead(4, resilience.FailFast)
+		fn := resilience.Wrap(limiter, resilience.Wrap(bulkhead, func(_ context.Context) core.Result[int] {
+			return internal.Ok(1)
+		}))
+		ctx := context.Background()
+		// [A]ct
+		first := fn(ctx)
+		second := fn(ctx)
+		// [A]ssert
+		must.True(t, first.IsOk())
+		var limited *resilience.ErrRateLimited
+		must.True(t, errors.As(second.UnwrapErr(), &limited))
+		must.Eq(t, 0, bulkhead.InFlight())
+	})
+
+	t.Run("Producer errors pass through", func(t *testing.T) {
+		t.Parallel()
+		// [A]rrange
+		expected := errors.New("downstream")
+		limiter := resilience.NewRateLimiter(1, 1, resilience.Wait)
+		// [A]ct
+		actual := resilience.Do(context.Background(), limiter, func(_ context.Context) core.Result[int] {
+			return internal.Err[int](expected)
+		})
+		// [A]ssert
+		must.Eq(t, expected, actual.UnwrapErr())
+	})
+}
@@ -0,0 +1,105 @@
+package resilience
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"codeberg.org/yaadata/opt/core"
+	"codeberg.org/yaadata/opt/internal"
+)
+
+// RateLimiter is a token-bucket Guard. The bucket holds up to burst tokens and
+// gains one token every interval. Each admitted call consumes one token.
+type RateLimiter struct {
+	mu       sync.Mutex
+	interval time.Duration
+	burst    float64
+	tokens   float64
+	last     time.Time
+	mode     Mode
+}
+
+// interface guard
+var _ Guard = (*RateLimiter)(nil)
+
+// NewRateLimiter creates a RateLimiter that starts with a full bucket.
+// Panics if interval is not positive or burst is less than one.
+//
+// Example:
+//
+//	// 10 calls per second, allowing bursts of 5
+//	limiter := NewRateLimiter(100*time.Millisecond, 5, Wait)
+func NewRateLimiter(interval time.Duration, burst int, mode Mode) *RateLimiter {
+	if interval <= 0 {
+		panic("resilience: rate limiter interval must be positive")
+	}
+	if burst < 1 {
+		panic("resilience: rate limiter burst must be at least one")
+	}
+	return &RateLimiter{
+		interval: interval,
+		burst:    float64(burst),
+		tokens:   float64(burst),
+		last:     time.Now(),
+		mode:     mode,
+	}
+}
+
+// Acquire consumes a token.
+//
+// In Wait mode, Acquire reserves the next token and sleeps until it is due.
+// If ctx ends first the reservation is returned to the bucket and ctx.Err() is the Err.
+//
+// In FailFast mode, Acquire returns Err(*ErrRateLimited) when no token is available.
+func (l *RateLimiter) Acquire(ctx context.Context) core.Result[Release] {
+	if err := ctx.Err(); err != nil {
+		return internal.Err[Release](err)
+	}
+	l.mu.Lock()
+	l.refill(time.Now())
+	if l.tokens >= 1 {
+		l.tokens--
+		l.mu.Unlock()
+		return internal.Ok[Release](func() {})
+	}
+	delay := l.delay()
+	if l.mode == FailFast {
+		l.mu.Unlock()
+		return internal.Err[Release](&ErrRateLimited{RetryAfter: delay})
+	}
+	// reserve the token so concurrent waiters queue behind this one
+	l.tokens--
+	l.mu.Unlock()
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return internal.Ok[Release](func() {})
+	case <-ctx.Done():
+		l.mu.Lock()
+		l.tokens++
+		l.mu.Unlock()
+		return internal.Err[Release](ctx.Err())
+	}
+}
+
+// refill must be called with l.mu held.
+func (l *RateLimiter) refill(now time.Time) {
+	elapsed := now.Sub(l.last)
+	if elapsed <= 0 {
+		return
+	}
+	l.last = now
+	l.tokens += float64(elapsed) / float64(l.interval)
+	if l.tokens > l.burst {
+		l.tokens = l.burst
+	}
+}
+
+// delay reports how long until one whole token is available.
+// It must be called with l.mu held.
+func (l *RateLimiter) delay() time.Duration {
+	return time.Duration((1 - l.tokens) * float64(l.interval))
+}
@@ -0,0 +1,118 @@
+package resilience_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"testing/synctest"
+	"time"
+
+	"github.com/shoenig/test/must"
+
+	"codeberg.org/yaadata/opt/resilience"
+)
+
+func TestRateLimiter(t *testing.T) {
+	t.Parallel()
+	t.Run("FailFast admits the burst then rejects with retry-after", func(t *testing.T) {
+		t.Parallel()
+		synctest.Test(t, func(t *testing.T) {
+			// [A]rrange
+			limiter := resilience.NewRateLimiter(100*time.Millisecond, 2, resilience.FailFast)
+			ctx := context.Background()
+			// [A]ct
+			first := limiter.Acquire(ctx)
+			second := limiter.Acquire(ctx)
+			third := limiter.Acquire(ctx)
+			// [A]ssert
+			must.True(t, first.IsOk())
+			must.True(t, second.IsOk())
+			must.True(t, third.IsError())
+			var limited *resilience.ErrRateLimited
+			must.True(t, errors.As(third.UnwrapErr(), &limited))
+			must.Eq(t, 100*time.Millisecond, limited.RetryAfter)
+		})
+	})
+
+	t.Run("FailFast admits again once a token refills", func(t *testing.T) {
+		t.Parallel()
+		synctest.Test(t, func(t *testing.T) {
+			// [A]rrange
+			limiter := resilience.NewRateLimiter(100*time.Millisecond, 1, resilience.FailFast)
+			ctx := context.Background()
+			must.True(t, limiter.Acquire(ctx).IsOk())
+			time.Sleep(40 * time.Millisecond)
+			rejected := limiter.Acquire(ctx)
+			// [A]ct
+			time.Sleep(60 * time.Millisecond)
+			actual := limiter.Acquire(ctx)
+			// [A]ssert
+			var limited *resilience.ErrRateLimited
+			must.True(t, errors.As(rejected.UnwrapErr(), &limited))
+			must.Eq(t, 60*time.Millisecond, limited.RetryAfter)
+			must.True(t, actual.IsOk())
+		})
+	})
+
+	t.Run("Wait blocks until the next token is due", func(t *testing.T) {
+		t.Parallel()
+		synctest.Test(t, func(t *testing.T) {
+			// [A]rrange
+			limiter := resilience.NewRateLimiter(100*time.Millisecond, 1, resilience.Wait)
+			ctx := context.Background()
+			start := time.Now()
+			// [A]ct
+			var actual []time.Duration
+			for range 3 {
+				must.True(t, limiter.Acquire(ctx).IsOk())
+				actual = append(actual, time.Since(start))
+			}
+			// [A]ssert
+			must.Eq(t, []time.Duration{0, 100 * time.Millisecond, 200 * time.Millisecond}, actual)
+		})
+	})
+
+	t.Run("Wait returns the context error when ctx ends first", func(t *testing.T) {
+		t.Parallel()
+		synctest.Test(t, func(t *testing.T) {
+			// [A]rrange
+			limiter := resilience.NewRateLimiter(time.Second, 1, resilience.Wait)
+			must.True(t, limiter.Acquire(context.Background()).IsOk())
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+			defer cancel()
+			// [A]ct
+			actual := limiter.Acquire(ctx)
+			// [A]ssert
+			must.True(t, actual.IsError())
+			must.ErrorIs(t, actual.UnwrapErr(), context.DeadlineExceeded)
+		})
+	})
+
+	t.Run("Cancelled wait gives its reservation back", func(t *testing.T) {
+		t.Parallel()
+		synctest.Test(t, func(t *testing.T) {
+			// [A]rrange
+			limiter := resilience.NewRateLimiter(100*time.Millisecond, 1, resilience.Wait)
+			must.True(t, limiter.Acquire(context.Background()).IsOk())
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+			defer cancel()
+			must.True(t, limiter.Acquire(ctx).IsError())
+			start := time.Now()
+			// [A]ct
+			actual := limiter.Acquire(context.Background())
+			// [A]ssert
+			must.True(t, actual.IsOk())
+			must.Eq(t, 90*time.Millisecond, time.Since(start))
+		})
+	})
+
+	t.Run("Invalid configuration panics", func(t *testing.T) {
+		t.Parallel()
+		// [A]ct
+		zeroInterval := func() { resilience.NewRateLimiter(0, 1, resilience.Wait) }
+		zeroBurst := func() { resilience.NewRateLimiter(time.Second, 0, resilience.Wait) }
+		// [A]ssert
+		must.Panic(t, zeroInterval)
+		must.Panic(t, zeroBurst)
+	})
+}
@@ -3,11 +3,13 @@ package optionsgo_test
 import (
 	"errors"
 	"fmt"
+	"os"
 	"testing"
 
 	"github.com/shoenig/test/must"
 
 	. "codeberg.org/yaadata/opt"
+	"codeberg.org/yaadata/opt/core"
 	"codeberg.org/yaadata/opt/extension"
 )
 
@@ -17,19 +19,43 @@ func TestResult_Error(t *testing.T) {
 		t.Parallel()
 		// [A]rrange
 		expected := "TEST"
+		cause := errors.New("err")
 		defer func() {
 			if rec := recover(); rec != nil {
-				r := extension.MustCast[string](rec)
-				must.Eq(t, expected, r)
+				err := extension.MustCast[error](rec)
+				must.EqError(t, err, "TEST: err")
+				must.ErrorIs(t, err, core.ErrUnwrapErr)
+				must.ErrorIs(t, err, cause)
 			} else {
 				t.Fail()
 			}
 		}()
-		result := Err[string](errors.New("err"))
+		result := Err[string](cause)
 		// [A]ct
 		result.Expect(expected)
 	})
 
+	t.Run("Unwrap panics with an UnwrapError", func(t *testing.T) {
+		t.Parallel()
+		// [A]rrange
+		cause := errors.New("connection refused")
+		result := Err[string](cause)
+		// [A]ct
+		actual := extension.Catch(func() string {
+			return result.Unwrap()
+		})
+		// [A]ssert
+		var unwrapErr *core.UnwrapError
+		must.True(t, errors.As(actual.UnwrapErr(), &unwrapErr))
+		must.Eq(t, "Result.Unwrap", unwrapErr.Method)
+		must.Eq(t, "cannot unwrap Err result to value", unwrapErr.Message)
+		must.Eq(t, core.UnwrapKindErr, unwrapErr.Kind)
+		must.Eq(t, cause, unwrapErr.Err)
+		must.StrHasSuffix(t, "result_test.go", unwrapErr.Caller.File)
+		must.StrContains(t, unwrapErr.Caller.Function, "TestResult_Error")
+		must.EqError(t, unwrapErr, "cannot unwrap Err result to value: connection refused")
+	})
+
 	t.Run("ExpectErr does not panic", func(t *testing.T) {
 		t.Parallel()
 		// [A]rrange
@@ -135,6 +161,62 @@ func TestResult_Error(t *testing.T) {
 		must.False(t, actual)
 	})
 
+	t.Run("Kind is KindErr", func(t *testing.T) {
+		t.Parallel()
+		// [A]rrange
+		result := Err[string](errors.New("error"))
+		// [A]ct
+		actual := result.Kind()
+		// [A]ssert
+		must.Eq(t, core.KindErr, actual)
+		must.Eq(t, "Err", actual.String())
+	})
+
+	t.Run("IsErrorIs matches wrapped target", func(t *testing.T) {
+		t.Parallel()
+		// [A]rrange
+		result := Err[string](fmt.Errorf("loading: %w", os.ErrNotExist))
+		// [A]ct
+		actual := result.IsErrorIs(os.ErrNotExist)
+		// [A]ssert
+		must.True(t, actual)
+		must.False(t, result.IsErrorIs(os.ErrExist))
+	})
+
+	t.Run("IsErrorIs is false for Ok", func(t *testing.T) {
+		t.Parallel()
+		// [A]rrange
+		result := Ok("value")
+		// [A]ct
+		actual := result.IsErrorIs(os.ErrNotExist)
+		// [A]ssert
+		must.False(t, actual)
+	})
+
+	t.Run("RecoverIs replaces matching Err", func(t *testing.T) {
+		t.Parallel()
+		// [A]rrange
+		result := Err[string](fmt.Errorf("loading: %w", os.ErrNotExist))
+		// [A]ct
+		actual := result.RecoverIs(os.ErrNotExist, func(err error) core.Result[string] {
+			return Ok("default")
+		})
+		// [A]ssert
+		must.Eq(t, "default", actual.Unwrap())
+	})
+
+	t.Run("RecoverIs passes other Err through", func(t *testing.T) {
+		t.Parallel()
+		// [A]rrange
+		result := Err[string](os.ErrPermission)
+		// [A]ct
+		actual := result.RecoverIs(os.ErrNotExist, func(err error) core.Result[string] {
+			return Ok("default")
+		})
+		// [A]ssert
+		must.ErrorIs(t, actual.UnwrapErr(), os.ErrPermission)
+	})
+
 	t.Run("MapErr returns transformed error", func(t *testing.T) {
 		t.Parallel()
 		// [A]rrange
@@ -186,6 +268,18 @@ func TestResult_Error(t *testing.T) {
 		must.Eq(t, expected, actual)
 	})
 
+	t.Run("Get returns zero value and the error", func(t *testing.T) {
+		t.Parallel()
+		// [A]rrange
+		cause := errors.New("error")
+		result := Err[string](cause)
+		// [A]ct
+		value, err := result.Get()
+		// [A]ssert
+		must.Eq(t, cause, err)
+		must.Eq(t, "", value)
+	})
+
 	t.Run("UnwrapOr returns Other option", func(t *testing.T) {
 		t.Parallel()
 		// [A]rrange
@@ -262,8 +356,9 @@ func TestResult_Value(t *testing.T) {
 		expected := "TEST"
 		defer func() {
 			if rec := recover(); rec != nil {
-				r := extension.MustCast[string](rec)
-				must.Eq(t, expected, r)
+				err := extension.MustCast[error](rec)
+				must.EqError(t, err, expected)
+				must.ErrorIs(t, err, core.ErrUnwrapOk)
 			} else {
 				t.Fail()
 			}
@@ -301,6 +396,17 @@ func TestResult_Value(t *testing.T) {
 		must.False(t, actual)
 	})
 
+	t.Run("Kind is KindOk", func(t *testing.T) {
+		t.Parallel()
+		// [A]rrange
+		result := Ok("value")
+		// [A]ct
+		actual := result.Kind()
+		// [A]ssert
+		must.Eq(t, core.KindOk, actual)
+		must.Eq(t, "Ok", actual.String())
+	})
+
 	t.Run("IsOk returns true", func(t *testing.T) {
 		t.Parallel()
 		// [A]rrange
@@ -387,6 +493,17 @@ func TestResult_Value(t *testing.T) {
 		must.Panic(t, fn)
 	})
 
+	t.Run("Get returns the value and nil", func(t *testing.T) {
+		t.Parallel()
+		// [A]rrange
+		result := Ok("value")
+		// [A]ct
+		value, err := result.Get()
+		// [A]ssert
+		must.NoError(t, err)
+		must.Eq(t, "value", value)
+	})
+
 	t.Run("UnwrapOr returns original value", func(t *testing.T) {
 		t.Parallel()
 		// [A]rrange
@@ -481,3 +598,89 @@ func TestResultChaining(t *testing.T) {
 		must.True(t, actual.IsError())
 	})
 }
+
+func TestResult_Format(t *testing.T) {
+	t.Parallel()
+	t.Run("Ok prints its value", func(t *testing.T) {
+		t.Parallel()
+		// [A]rrange
+		result := Ok(42)
+		// [A]ct
+		actual := fmt.Sprintf("%v", result)
+		// [A]ssert
+		must.Eq(t, "Ok(42)", actual)
+	})
+
+	t.Run("Flags are applied to the value", func(t *testing.T) {
+		t.Parallel()
+		// [A]rrange
+		result := Ok(struct{ Name string }{Name: "a"})
+		// [A]ct
+		actual := fmt.Sprintf("%+v", result)
+		// [A]ssert
+		must.Eq(t, "Ok({Name:a})", actual)
+	})
+
+	t.Run("Err prints its error", func(t *testing.T) {
+		t.Parallel()
+		// [A]rrange
+		result := Err[int](errors.New("error"))
+		// [A]ct
+		actual := fmt.Sprintf("%s", result)
+		// [A]ssert
+		must.Eq(t, "Err(error)", actual)
+	})
+}
+
+func TestResult_Context(t *testing.T) {
+	t.Parallel()
+	t.Run("Context prefixes the error and keeps it matchable", func(t *testing.T) {
+		t.Parallel()
+		// [A]rrange
+		cause := errors.New("not found")
+		result := Err[string](cause)
+		// [A]ct
+		actual := result.Context("loading user")
+		// [A]ssert
+		must.EqError(t, actual.UnwrapErr(), "loading user: not found")
+		must.ErrorIs(t, actual.UnwrapErr(), cause)
+	})
+
+	t.Run("Contextf formats the message", func(t *testing.T) {
+		t.Parallel()
+		// [A]rrange
+		result := Err[string](errors.New("not found"))
+		// [A]ct
+		actual := result.Contextf("loading user %d", 42).Context("rendering")
+		// [A]ssert
+		must.EqError(t, actual.UnwrapErr(), "rendering: loading user 42: not found")
+	})
+
+	t.Run("WithField keeps the message and errors.As", func(t *testing.T) {
+		t.Parallel()
+		// [A]rrange
+		cause := &os.PathError{Op: "open", Path: "config.yaml", Err: os.ErrNotExist}
+		result := Err[string](cause)
+		// [A]ct
+		actual := result.WithField("path", "config.yaml")
+		// [A]ssert
+		must.EqError(t, actual.UnwrapErr(), cause.Error())
+		var pathErr *os.PathError
+		must.True(t, errors.As(actual.UnwrapErr(), &pathErr))
+		must.ErrorIs(t, actual.UnwrapErr(), os.ErrNotExist)
+		must.Eq(t, []extension.Field{{Key: "path", Value: "config.yaml"}}, extension.Fields(actual.UnwrapErr()))
+	})
+
+	t.Run("Ok is unchanged", func(t *testing.T) {
+		t.Parallel()
+		// [A]rrange
+		result := Ok("value")
+		// [A]ct
+		actual := result.
+			Context("loading").
+			Contextf("loading %d", 1).
+			WithField("key", "value")
+		// [A]ssert
+		must.Eq(t, "value", actual.Unwrap())
+	})
+}
@@ -0,0 +1,7 @@
+// Package saga runs a sequence of Result-returning steps as a saga.
+//
+// Each step pairs an action with a compensation. Steps run in order; the first
+// step to return Err stops the saga and the compensations of every step that
+// already completed run in reverse order. Progress can be persisted through a
+// Store so that a saga interrupted by a crash can be resumed.
+package saga
@@ -0,0 +1,53 @@
+package saga
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Error is returned when a saga step fails.
+// It carries the original failure and every compensation that failed while
+// rolling back. errors.Is and errors.As see all of them.
+type Error struct {
+	// Step is the name of the step whose action failed.
+	Step string
+	// Cause is the error returned by the failed step.
+	Cause error
+	// Compensations holds the compensations that failed, in the order they ran.
+	Compensations []*CompensationError
+}
+
+// CompensationError is a failed compensation.
+type CompensationError struct {
+	// Step is the name of the step whose compensation failed.
+	Step string
+	// Err is the error returned by the compensation.
+	Err error
+}
+
+func (e *Error) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "saga step %q failed: %v", e.Step, e.Cause)
+	for _, c := range e.Compensations {
+		b.WriteString("; ")
+		b.WriteString(c.Error())
+	}
+	return b.String()
+}
+
+func (e *Error) Unwrap() []error {
+	errs := make([]error, 0, len(e.Compensations)+1)
+	errs = append(errs, e.Cause)
+	for _, c := range e.Compensations {
+		errs = append(errs, c)
+	}
+	return errs
+}
+
+func (e *CompensationError) Error() string {
+	return fmt.Sprintf("compensation for step %q failed: %v", e.Step, e.Err)
+}
+
+func (e *CompensationError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,146 @@
+package saga
+
+import (
+	"context"
+	"fmt"
+
+	"codeberg.org/yaadata/opt/core"
+	"codeberg.org/yaadata/opt/internal"
+)
+
+// Saga is an ordered list of steps identified by an id.
+type Saga struct {
+	id    string
+	steps []Step
+	store Store
+}
+
+// progress is the state of a saga rebuilt from its log.
+type progress struct {
+	values      Values
+	compensated map[string]bool
+	failed      *Entry
+}
+
+// New creates a Saga. The id identifies the saga's log in a Store.
+// Progress is recorded by step name, so New panics if two steps share a name.
+//
+// Example:
+//
+//	s := New("order-42", reserve, charge, ship)
+//	res := s.Run(ctx)
+func New(id string, steps ...Step) *Saga {
+	seen := make(map[string]bool, len(steps))
+	for _, step := range steps {
+		if seen[step.name] {
+			panic(fmt.Sprintf("saga: duplicate step %q", step.name))
+		}
+		seen[step.name] = true
+	}
+	return &Saga{id: id, steps: steps}
+}
+
+// WithStore makes the saga persist its execution log to store.
+// Running a saga whose id already has a log in the store resumes it:
+// completed steps are not run again, and a saga that had failed finishes
+// its compensations.
+func (s *Saga) WithStore(store Store) *Saga {
+	s.store = store
+	return s
+}
+
+// Run executes the saga.
+//
+// If every step returns Ok, Run returns Ok with the value of each step.
+// Otherwise the completed steps are compensated in reverse order and Run
+// returns Err(*Error) holding the failure and any compensation errors.
+// If the store cannot be read or written, Run stops and returns that error;
+// running the saga again resumes from the last persisted entry.
+func (s *Saga) Run(ctx context.Context) core.Result[Values] {
+	state := s.load(ctx)
+	if state.IsError() {
+		return internal.ErrFrom[Values](state)
+	}
+	p := state.Unwrap()
+	if p.failed != nil {
+		return s.rollback(ctx, p, *p.failed)
+	}
+	for i, step := range s.steps {
+		if _, done := p.values[step.name]; done {
+			continue
+		}
+		res := step.action(ctx)
+		if res.IsError() {
+			failed := Entry{Step: step.name, Status: StepFailed, Err: res.UnwrapErr()}
+			if err := s.record(ctx, failed); err != nil {
+				return internal.Err[Values](err)
+			}
+			return s.rollback(ctx, p, failed, s.steps[:i]...)
+		}
+		value := res.Unwrap()
+		if err := s.record(ctx, Entry{Step: step.name, Status: StepCompleted, Value: value}); err != nil {
+			return internal.Err[Values](err)
+		}
+		p.values[step.name] = value
+	}
+	return internal.Ok(p.values)
+}
+
+// rollback compensates, in reverse order, the completed steps that have not
+// been compensated yet. Without explicit steps it considers every step.
+func (s *Saga) rollback(ctx context.Context, p progress, failed Entry, steps ...Step) core.Result[Values] {
+	if steps == nil {
+		steps = s.steps
+	}
+	sagaErr := &Error{Step: failed.Step, Cause: failed.Err}
+	for i := len(steps) - 1; i >= 0; i-- {
+		step := steps[i]
+		value, done := p.values[step.name]
+		if !done || p.compensated[step.name] || step.compensate == nil {
+			continue
+		}
+		entry := Entry{Step: step.name, Status: StepCompensated}
+		if err := step.compensate(ctx, value); err != nil {
+			entry = Entry{Step: step.name, Status: StepCompensationFailed, Err: err}
+			sagaErr.Compensations = append(sagaErr.Compensations, &CompensationError{Step: step.name, Err: err})
+		}
+		if err := s.record(ctx, entry); err != nil {
+			return internal.Err[Values](err)
+		}
+	}
+	return internal.Err[Values](sagaErr)
+}
+
+func (s *Saga) load(ctx context.Context) core.Result[progress] {
+	p := progress{values: Values{}, compensated: map[string]bool{}}
+	if s.store == nil {
+		return internal.Ok(p)
+	}
+	entries := s.store.Load(ctx, s.id)
+	if entries.IsError() {
+		return internal.Err[progress](fmt.Errorf("saga %q: load log: %w", s.id, entries.UnwrapErr()))
+	}
+	for _, entry := range entries.Unwrap() {
+		switch entry.Status {
+		case StepCompleted:
+			p.values[entry.Step] = entry.Value
+		case StepFailed:
+			p.failed = &entry
+		case StepCompensated:
+			p.compensated[entry.Step] = true
+		case StepCompensationFailed:
+			// the compensation is retried on resume
+		}
+	}
+	return internal.Ok(p)
+}
+
+func (s *Saga) record(ctx context.Context, entry Entry) error {
+	if s.store == nil {
+		return nil
+	}
+	if err := s.store.Append(ctx, s.id, entry); err != nil {
+		return fmt.Errorf("saga %q: append log: %w", s.id, err)
+	}
+	return nil
+}
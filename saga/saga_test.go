@@ -0,0 +1,239 @@
+package saga_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/shoenig/test/must"
+
+	"codeberg.org/yaadata/opt/core"
+	"codeberg.org/yaadata/opt/internal"
+	"codeberg.org/yaadata/opt/saga"
+)
+
+type journal struct {
+	events []string
+}
+
+func (j *journal) step(name string, fail error, compensateFail error) saga.Step {
+	return saga.NewStep(name,
+		func(_ context.Context) core.Result[string] {
+			j.events = append(j.events, "run "+name)
+			if fail != nil {
+				return internal.Err[string](fail)
+			}
+			return internal.Ok(name + "-value")
+		},
+		func(_ context.Context, value string) error {
+			j.events = append(j.events, "undo "+value)
+			return compensateFail
+		},
+	)
+}
+
+type failingStore struct {
+	saga.Store
+	failOn saga.Status
+}
+
+func (f failingStore) Append(ctx context.Context, id string, entry saga.Entry) error {
+	if entry.Status == f.failOn {
+		return errors.New("disk full")
+	}
+	return f.Store.Append(ctx, id, entry)
+}
+
+func TestSaga_Run(t *testing.T) {
+	t.Parallel()
+	t.Run("All steps Ok returns every value", func(t *testing.T) {
+		t.Parallel()
+		// [A]rrange
+		j := &journal{}
+		s := saga.New("id", j.step("a", nil, nil), j.step("b", nil, nil))
+		// [A]ct
+		actual := s.Run(context.Background())
+		// [A]ssert
+		must.True(t, actual.IsOk())
+		must.Eq(t, saga.Values{"a": "a-value", "b": "b-value"}, actual.Unwrap())
+		must.Eq(t, []string{"run a", "run b"}, j.events)
+	})
+
+	t.Run("Failure compensates completed steps in reverse", func(t *testing.T) {
+		t.Parallel()
+		// [A]rrange
+		j := &journal{}
+		cause := errors.New("card declined")
+		s := saga.New("id", j.step("a", nil, nil), j.step("b", nil, nil), j.step("c", cause, nil), j.step("d", nil, nil))
+		// [A]ct
+		actual := s.Run(context.Background())
+		// [A]ssert
+		must.Eq(t, []string{"run a", "run b", "run c", "undo b-value", "undo a-value"}, j.events)
+		must.ErrorIs(t, actual.UnwrapErr(), cause)
+		var sagaErr *saga.Error
+		must.True(t, errors.As(actual.UnwrapErr(), &sagaErr))
+		must.Eq(t, "c", sagaErr.Step)
+		must.SliceEmpty(t, sagaErr.Compensations)
+	})
+
+	t.Run("Compensation failures are joined with the cause", func(t *testing.T) {
+		t.Parallel()
+		// [A]rrange
+		j := &journal{}
+		cause := errors.New("card declined")
+		undoErr := errors.New("release failed")
+		s := saga.New("id", j.step("a", nil, nil), j.step("b", nil, undoErr), j.step("c", cause, nil))
+		// [A]ct
+		actual := s.Run(context.Background())
+		// [A]ssert
+		must.Eq(t, []string{"run a", "run b", "run c", "undo b-value", "undo a-value"}, j.events)
+		err := actual.UnwrapErr()
+		must.ErrorIs(t, err, cause)
+		must.ErrorIs(t, err, undoErr)
+		must.Eq(t,
+			`saga step "c" failed: card declined; compensation for step "b" failed: release failed`,
+			err.Error(),
+		)
+	})
+
+	t.Run("Nil compensation is skipped", func(t *testing.T) {
+		t.Parallel()
+		// [A]rrange
+		readOnly := saga.NewStep[int]("read", func(_ context.Context) core.Result[int] {
+			return internal.Ok(1)
+		}, nil)
+		failing := saga.NewStep[int]("write", func(_ context.Context) core.Result[int] {
+			return internal.Err[int](errors.New("conflict"))
+		}, nil)
+		// [A]ct
+		actual := saga.New("id", readOnly, failing).Run(context.Background())
+		// [A]ssert
+		must.EqError(t, actual.UnwrapErr(), `saga step "write" failed: conflict`)
+	})
+
+	t.Run("Nil interface value is compensated", func(t *testing.T) {
+		t.Parallel()
+		// [A]rrange
+		var compensated []any
+		open := saga.NewStep[io.Closer]("open",
+			func(_ context.Context) core.Result[io.Closer] {
+				return internal.Ok[io.Closer](nil)
+			},
+			func(_ context.Context, closer io.Closer) error {
+				compensated = append(compensated, closer)
+				return nil
+			},
+		)
+		failing := saga.NewStep[int]("write", func(_ context.Context) core.Result[int] {
+			return internal.Err[int](errors.New("conflict"))
+		}, nil)
+		// [A]ct
+		actual := saga.New("id", open, failing).Run(context.Background())
+		// [A]ssert
+		must.EqError(t, actual.UnwrapErr(), `saga step "write" failed: conflict`)
+		must.Eq(t, []any{nil}, compensated)
+	})
+}
+
+func TestNew(t *testing.T) {
+	t.Parallel()
+	t.Run("Duplicate step name panics", func(t *testing.T) {
+		t.Parallel()
+		// [A]rrange
+		j := &journal{}
+		// [A]ct
+		duplicate := func() { saga.New("id", j.step("charge", nil, nil), j.step("charge", nil, nil)) }
+		// [A]ssert
+		must.Panic(t, duplicate)
+		must.SliceEmpty(t, j.events)
+	})
+}
+
+func TestSaga_Resume(t *testing.T) {
+	t.Parallel()
+	t.Run("Completed steps are not run again", func(t *testing.T) {
+		t.Parallel()
+		// [A]rrange
+		store := saga.NewMemoryStore()
+		crashed := &journal{}
+		interrupted := failingStore{Store: store, failOn: saga.StepCompleted}
+		first := saga.New("order", crashed.step("a", nil, nil)).WithStore(store)
+		must.True(t, first.Run(context.Background()).IsOk())
+		partial := saga.New("order", crashed.step("a", nil, nil), crashed.step("b", nil, nil)).WithStore(interrupted)
+		must.True(t, partial.Run(context.Background()).IsError())
+		resumed := &journal{}
+		s := saga.New("order", resumed.step("a", nil, nil), resumed.step("b", nil, nil)).WithStore(store)
+		// [A]ct
+		actual := s.Run(context.Background())
+		// [A]ssert
+		must.Eq(t, []string{"run b"}, resumed.events)
+		must.Eq(t, saga.Values{"a": "a-value", "b": "b-value"}, actual.Unwrap())
+	})
+
+	t.Run("Failed saga finishes its compensations", func(t *testing.T) {
+		t.Parallel()
+		// [A]rrange
+		store := saga.NewMemoryStore()
+		cause := errors.New("card declined")
+		crashed := &journal{}
+		interrupted := failingStore{Store: store, failOn: saga.StepCompensated}
+		steps := func(j *journal) []saga.Step {
+			return []saga.Step{j.step("a", nil, nil), j.step("b", cause, nil)}
+		}
+		must.True(t, saga.New("order", steps(crashed)...).WithStore(interrupted).Run(context.Background()).IsError())
+		resumed := &journal{}
+		s := saga.New("order", steps(resumed)...).WithStore(store)
+		// [A]ct
+		actual := s.Run(context.Background())
+		// [A]ssert
+		must.Eq(t, []string{"undo a-value"}, resumed.events)
+		must.ErrorIs(t, actual.UnwrapErr(), cause)
+		entries := store.Load(context.Background(), "order").Unwrap()
+		must.Eq(t, saga.StepCompensated, entries[len(entries)-1].Status)
+	})
+
+	t.Run("Failed compensation is retried", func(t *testing.T) {
+		t.Parallel()
+		// [A]rrange
+		store := saga.NewMemoryStore()
+		cause := errors.New("card declined")
+		crashed := &journal{}
+		first := saga.New("order", crashed.step("a", nil, errors.New("timeout")), crashed.step("b", cause, nil))
+		must.True(t, first.WithStore(store).Run(context.Background()).IsError())
+		resumed := &journal{}
+		s := saga.New("order", resumed.step("a", nil, nil), resumed.step("b", cause, nil)).WithStore(store)
+		// [A]ct
+		actual := s.Run(context.Background())
+		// [A]ssert
+		must.Eq(t, []string{"undo a-value"}, resumed.events)
+		var sagaErr *saga.Error
+		must.True(t, errors.As(actual.UnwrapErr(), &sagaErr))
+		must.SliceEmpty(t, sagaErr.Compensations)
+	})
+}
+
+func TestGet(t *testing.T) {
+	t.Parallel()
+	t.Run("Completed step value is Some", func(t *testing.T) {
+		t.Parallel()
+		// [A]rrange
+		values := saga.Values{"a": 42}
+		// [A]ct
+		actual := saga.Get[int](values, "a")
+		// [A]ssert
+		must.Eq(t, 42, actual.Unwrap())
+	})
+
+	t.Run("Missing step or wrong type is None", func(t *testing.T) {
+		t.Parallel()
+		// [A]rrange
+		values := saga.Values{"a": 42}
+		// [A]ct
+		missing := saga.Get[int](values, "b")
+		wrongType := saga.Get[string](values, "a")
+		// [A]ssert
+		must.True(t, missing.IsNone())
+		must.True(t, wrongType.IsNone())
+	})
+}
@@ -0,0 +1,77 @@
+package saga
+
+import (
+	"context"
+
+	"codeberg.org/yaadata/opt/core"
+	"codeberg.org/yaadata/opt/extension"
+	"codeberg.org/yaadata/opt/internal"
+)
+
+// Step is a named action paired with the compensation that undoes it.
+type Step struct {
+	name       string
+	action     func(ctx context.Context) core.Result[any]
+	compensate func(ctx context.Context, value any) error
+}
+
+// Values holds the Ok value of every completed step, keyed by step name.
+type Values map[string]any
+
+// NewStep creates a Step. The compensation receives the value the action
+// produced and may be nil when the action has nothing to undo.
+//
+// Example:
+//
+//	reserve := NewStep("reserve",
+//	    func(ctx context.Context) core.Result[ReservationID] {
+//	        return inventory.Reserve(ctx, sku)
+//	    },
+//	    func(ctx context.Context, id ReservationID) error {
+//	        return inventory.Release(ctx, id)
+//	    },
+//	)
+func NewStep[T any](
+	name string,
+	action func(ctx context.Context) core.Result[T],
+	compensate func(ctx context.Context, value T) error,
+) Step {
+	step := Step{
+		name: name,
+		action: func(ctx context.Context) core.Result[any] {
+			return extension.ResultMap(action(ctx), func(value T) any { return value })
+		},
+	}
+	if compensate != nil {
+		step.compensate = func(ctx context.Context, value any) error {
+			// a nil value of an interface type T does not assert; it becomes the zero T
+			typed, _ := value.(T)
+			return compensate(ctx, typed)
+		}
+	}
+	return step
+}
+
+// Name returns the step name.
+func (s Step) Name() string {
+	return s.name
+}
+
+// Get returns the value produced by the named step.
+// Returns None if the step did not complete or produced a value of another type.
+//
+// Example:
+//
+//	values := saga.Run(ctx).Unwrap()
+//	id := Get[ReservationID](values, "reserve") // Some(id)
+func Get[T any](values Values, name string) core.Option[T] {
+	value, ok := values[name]
+	if !ok {
+		return internal.None[T]()
+	}
+	typed, ok := value.(T)
+	if !ok {
+		return internal.None[T]()
+	}
+	return internal.Some(typed)
+}
@@ -0,0 +1,73 @@
+package saga
+
+import (
+	"context"
+	"slices"
+	"sync"
+
+	"codeberg.org/yaadata/opt/core"
+	"codeberg.org/yaadata/opt/internal"
+)
+
+const (
+	// StepCompleted records that a step's action returned Ok.
+	StepCompleted Status = iota
+	// StepFailed records that a step's action returned Err.
+	StepFailed
+	// StepCompensated records that a step's compensation succeeded.
+	StepCompensated
+	// StepCompensationFailed records that a step's compensation returned an error.
+	StepCompensationFailed
+)
+
+// Status is the kind of event an Entry records.
+type Status int
+
+// Entry is one event in a saga's execution log.
+type Entry struct {
+	Step   string
+	Status Status
+	// Value is the Ok value of a StepCompleted entry. Stores must hand back
+	// a value of the same dynamic type so compensations can receive it.
+	Value any
+	// Err is the error of a StepFailed or StepCompensationFailed entry.
+	Err error
+}
+
+// Store persists saga execution logs.
+type Store interface {
+	// Append adds an entry to the log of the saga with the given id.
+	Append(ctx context.Context, id string, entry Entry) error
+	// Load returns the log of the saga with the given id in append order.
+	// A saga that has never run has an empty log.
+	Load(ctx context.Context, id string) core.Result[[]Entry]
+}
+
+// MemoryStore is a Store that keeps logs in memory.
+type MemoryStore struct {
+	mu   sync.Mutex
+	logs map[string][]Entry
+}
+
+// interface guard
+var _ Store = (*MemoryStore)(nil)
+
+// NewMemoryStore creates an empty MemoryStore.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{logs: map[string][]Entry{}}
+}
+
+// Append adds an entry to the saga's log.
+func (m *MemoryStore) Append(_ context.Context, id string, entry Entry) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.logs[id] = append(m.logs[id], entry)
+	return nil
+}
+
+// Load returns a copy of the saga's log.
+func (m *MemoryStore) Load(_ context.Context, id string) core.Result[[]Entry] {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return internal.Ok(slices.Clone(m.logs[id]))
+}
@@ -0,0 +1,12 @@
+// Package validate checks values against rules and reports every violation
+// with the path of the field that broke it.
+//
+// Structs are validated from `validate` struct tags with Struct, which
+// recurses into nested structs, slices of structs and core.Option fields.
+// Single values are validated with Check and typed rules such as Required,
+// Min and Regex, and combined with extension.ValidatedMap2 to
+// extension.ValidatedMap8.
+//
+// Violations are reported as a *core.MultiError of *core.ValidationError,
+// each wrapping a *RuleError that names the rule.
+package validate
@@ -0,0 +1,16 @@
+package validate
+
+// RuleError is a violation of a single rule.
+type RuleError struct {
+	// Rule is the name of the rule, such as "required" or "min".
+	Rule string
+	// Param is the parameter of the rule, such as "3" for min=3. It is empty
+	// for rules without a parameter.
+	Param string
+	// Msg describes the violation, such as "must be at least 3".
+	Msg string
+}
+
+func (e *RuleError) Error() string {
+	return e.Msg
+}
@@ -0,0 +1,178 @@
+package validate
+
+import (
+	"cmp"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"codeberg.org/yaadata/opt/core"
+	"codeberg.org/yaadata/opt/internal"
+)
+
+// Rule checks a value and returns a non-nil error if it is invalid.
+// Any func(T) error can be used as a custom rule.
+type Rule[T any] func(value T) error
+
+// Check runs every rule against value and returns Valid if all of them pass.
+// Otherwise it returns Invalid with the error of every failing rule. Use At on
+// the result to set the field path.
+//
+// Example:
+//
+//	zip := Check(form.Zip, Required[string](), Regex(`^[0-9]{5}$`)).At("zip")
+//	zip.Result().UnwrapErr().Error() // "zip: must match ^[0-9]{5}$"
+func Check[T any](value T, rules ...Rule[T]) core.Validated[T] {
+	var errs []error
+	for _, rule := range rules {
+		if err := rule(value); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) > 0 {
+		return internal.Invalid[T](errs...)
+	}
+	return internal.Valid(value)
+}
+
+// Required fails if value is the zero value of T.
+func Required[T comparable]() Rule[T] {
+	return func(value T) error {
+		if value == *new(T) {
+			return requiredError()
+		}
+		return nil
+	}
+}
+
+// Min fails if value is less than n.
+func Min[T cmp.Ordered](n T) Rule[T] {
+	return func(value T) error {
+		if value < n {
+			return minError(fmt.Sprint(n))
+		}
+		return nil
+	}
+}
+
+// Max fails if value is greater than n.
+func Max[T cmp.Ordered](n T) Rule[T] {
+	return func(value T) error {
+		if value > n {
+			return maxError(fmt.Sprint(n))
+		}
+		return nil
+	}
+}
+
+// Len fails if value does not have exactly n characters.
+func Len[S ~string](n int) Rule[S] {
+	return func(value S) error {
+		if utf8.RuneCountInString(string(value)) != n {
+			return lenError(strconv.Itoa(n))
+		}
+		return nil
+	}
+}
+
+// Regex fails if value does not match pattern.
+// It panics if pattern does not compile.
+func Regex[S ~string](pattern string) Rule[S] {
+	re := regexp.MustCompile(pattern)
+	return func(value S) error {
+		if !re.MatchString(string(value)) {
+			return regexError(pattern)
+		}
+		return nil
+	}
+}
+
+// OneOf fails if value is not one of values.
+func OneOf[T comparable](values ...T) Rule[T] {
+	return func(value T) error {
+		for _, allowed := range values {
+			if value == allowed {
+				return nil
+			}
+		}
+		names := make([]string, len(values))
+		for i, allowed := range values {
+			names[i] = fmt.Sprint(allowed)
+		}
+		return oneOfError(strings.Join(names, " "))
+	}
+}
+
+// Some fails if the Option is None, and otherwise runs rules against the
+// contained value, failing with the first rule that fails. It is the Option
+// form of Required.
+//
+// Example:
+//
+//	Check(form.Nickname, Some(Len[string](8)))
+func Some[T any](rules ...Rule[T]) Rule[core.Option[T]] {
+	return func(opt core.Option[T]) error {
+		if opt == nil || opt.IsNone() {
+			return requiredError()
+		}
+		return firstError(opt.Unwrap(), rules)
+	}
+}
+
+// IfSome runs rules against the contained value if the Option is Some,
+// failing with the first rule that fails. None passes.
+//
+// Example:
+//
+//	Check(form.Age, IfSome(Min(18), Max(130)))
+func IfSome[T any](rules ...Rule[T]) Rule[core.Option[T]] {
+	return func(opt core.Option[T]) error {
+		if opt == nil || opt.IsNone() {
+			return nil
+		}
+		return firstError(opt.Unwrap(), rules)
+	}
+}
+
+func firstError[T any](value T, rules []Rule[T]) error {
+	for _, rule := range rules {
+		if err := rule(value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func requiredError() error {
+	return &RuleError{Rule: "required", Msg: "is required"}
+}
+
+func minError(param string) error {
+	return &RuleError{Rule: "min", Param: param, Msg: "must be at least " + param}
+}
+
+func maxError(param string) error {
+	return &RuleError{Rule: "max", Param: param, Msg: "must be at most " + param}
+}
+
+func minLenError(param string) error {
+	return &RuleError{Rule: "min", Param: param, Msg: "must have length at least " + param}
+}
+
+func maxLenError(param string) error {
+	return &RuleError{Rule: "max", Param: param, Msg: "must have length at most " + param}
+}
+
+func lenError(param string) error {
+	return &RuleError{Rule: "len", Param: param, Msg: "must have length " + param}
+}
+
+func regexError(param string) error {
+	return &RuleError{Rule: "regex", Param: param, Msg: "must match " + param}
+}
+
+func oneOfError(param string) error {
+	return &RuleError{Rule: "oneof", Param: param, Msg: "must be one of " + param}
+}
@@ -0,0 +1,112 @@
+package validate_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/shoenig/test/must"
+
+	"codeberg.org/yaadata/opt/core"
+	"codeberg.org/yaadata/opt/extension"
+	"codeberg.org/yaadata/opt/internal"
+	"codeberg.org/yaadata/opt/validate"
+)
+
+func TestCheck(t *testing.T) {
+	t.Parallel()
+	t.Run("Every rule passes", func(t *testing.T) {
+		t.Parallel()
+		// [A]ct
+		actual := validate.Check("12345",
+			validate.Required[string](),
+			validate.Len[string](5),
+			validate.Regex[string](`^[0-9]+$`),
+		)
+		// [A]ssert
+		must.Eq(t, "12345", actual.Result().Unwrap())
+	})
+
+	t.Run("Every failing rule is reported", func(t *testing.T) {
+		t.Parallel()
+		// [A]ct
+		actual := validate.Check("12a", validate.Len[string](5), validate.Regex[string](`^[0-9]+$`)).At("zip")
+		// [A]ssert
+		must.EqError(t, actual.Result().UnwrapErr(), "zip: must have length 5\nzip: must match ^[0-9]+$")
+	})
+
+	t.Run("Min, Max and OneOf", func(t *testing.T) {
+		t.Parallel()
+		// [A]ct
+		low := validate.Check(0, validate.Min(1), validate.Max(10))
+		high := validate.Check(11, validate.Min(1), validate.Max(10))
+		role := validate.Check("owner", validate.OneOf("admin", "member"))
+		// [A]ssert
+		must.EqError(t, low.Result().UnwrapErr(), "must be at least 1")
+		must.EqError(t, high.Result().UnwrapErr(), "must be at most 10")
+		must.EqError(t, role.Result().UnwrapErr(), "must be one of admin member")
+	})
+
+	t.Run("Required fails on zero value", func(t *testing.T) {
+		t.Parallel()
+		// [A]ct
+		actual := validate.Check("", validate.Required[string]())
+		// [A]ssert
+		ruleErr := extension.ErrorAs[*validate.RuleError](actual.Result()).Unwrap()
+		must.Eq(t, "required", ruleErr.Rule)
+	})
+
+	t.Run("Custom rule", func(t *testing.T) {
+		t.Parallel()
+		// [A]rrange
+		errOdd := errors.New("must be even")
+		even := func(value int) error {
+			if value%2 != 0 {
+				return errOdd
+			}
+			return nil
+		}
+		// [A]ct
+		actual := validate.Check(3, even)
+		// [A]ssert
+		must.ErrorIs(t, actual.Result().UnwrapErr(), errOdd)
+	})
+
+	t.Run("Combines with ValidatedMap2", func(t *testing.T) {
+		t.Parallel()
+		// [A]rrange
+		name := validate.Check("", validate.Required[string]()).At("name")
+		age := validate.Check(12, validate.Min(18)).At("age")
+		// [A]ct
+		actual := extension.ValidatedMap2(name, age, func(name string, age int) string { return name }).At("user")
+		// [A]ssert
+		must.EqError(t, actual.Result().UnwrapErr(), "user.name: is required\nuser.age: must be at least 18")
+	})
+}
+
+func TestOptionRules(t *testing.T) {
+	t.Parallel()
+	t.Run("Some requires a value", func(t *testing.T) {
+		t.Parallel()
+		// [A]ct
+		none := validate.Check(internal.None[string](), validate.Some(validate.Len[string](3)))
+		short := validate.Check(internal.Some("ab"), validate.Some(validate.Len[string](3)))
+		valid := validate.Check(internal.Some("abc"), validate.Some(validate.Len[string](3)))
+		// [A]ssert
+		must.EqError(t, none.Result().UnwrapErr(), "is required")
+		must.EqError(t, short.Result().UnwrapErr(), "must have length 3")
+		must.True(t, valid.IsValid())
+	})
+
+	t.Run("IfSome skips None", func(t *testing.T) {
+		t.Parallel()
+		// [A]ct
+		none := validate.Check(internal.None[int](), validate.IfSome(validate.Min(18)))
+		young := validate.Check(internal.Some(12), validate.IfSome(validate.Min(18)))
+		var unset core.Option[int]
+		zero := validate.Check(unset, validate.IfSome(validate.Min(18)))
+		// [A]ssert
+		must.True(t, none.IsValid())
+		must.True(t, zero.IsValid())
+		must.EqError(t, young.Result().UnwrapErr(), "must be at least 18")
+	})
+}
@@ -0,0 +1,417 @@
+package validate
+
+import (
+	"cmp"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"codeberg.org/yaadata/opt/core"
+	"codeberg.org/yaadata/opt/internal"
+)
+
+const tagName = "validate"
+
+// StructOption configures Struct.
+type StructOption func(*engine)
+
+// WithRule registers a custom rule that struct tags refer to by name.
+// fn receives the field value and returns a non-nil error if it is invalid.
+//
+// Example:
+//
+//	type Order struct {
+//	    SKU string `validate:"required,sku"`
+//	}
+//	result := Struct(order, WithRule("sku", checkSKU))
+func WithRule(name string, fn func(value any) error) StructOption {
+	return func(e *engine) {
+		e.custom[name] = fn
+	}
+}
+
+type engine struct {
+	custom    map[string]func(value any) error
+	ancestors map[visit]struct{}
+}
+
+// visit identifies a pointer on the path the engine is descending, so that
+// a cyclic struct graph stops instead of recursing forever.
+type visit struct {
+	ptr uintptr
+	typ reflect.Type
+}
+
+type fieldPlan struct {
+	index int
+	name  string
+	rules []tagRule
+}
+
+type tagRule struct {
+	name  string
+	param string
+	re    *regexp.Regexp
+}
+
+// plans caches the parsed fields of each struct type.
+var plans sync.Map // map[reflect.Type][]fieldPlan
+
+// optionLike is implemented by every core.Option.
+type optionLike interface {
+	IsSome() bool
+	Kind() core.OptionKind
+}
+
+var optionType = reflect.TypeFor[optionLike]()
+
+// Struct validates value against the `validate` tags of its fields and
+// returns Ok(value) if every rule passes. Otherwise it returns
+// Err(*core.MultiError) holding a *core.ValidationError for every violation.
+//
+// A tag is a comma-separated list of rules:
+//
+//	required      the field is not zero or empty; a core.Option is Some and a
+//	              pointer is not nil
+//	min=N, max=N  numbers are at least or at most N; strings, slices and maps
+//	              have at least or at most N characters or elements
+//	len=N         strings, slices and maps have exactly N characters or elements
+//	regex=P       strings match the regular expression P, which cannot contain a comma
+//	oneof=A B C   the field, formatted with fmt.Sprint, is one of the listed values
+//	name          a custom rule registered with WithRule
+//
+// Rules other than required apply to a core.Option field only when it is
+// Some, and then to the contained value. A pointer field is treated the same
+// way: a nil pointer is checked only by required, and the other rules apply
+// to the value a non-nil pointer points to.
+//
+// Struct recurses into nested structs, pointers to structs, slices and
+// arrays of structs and Options containing structs. Paths use the json name
+// of a field when it has one, such as "items[2].sku". A pointer shared by
+// several fields is checked at each of their paths, and a pointer back to a
+// struct that is already being checked, as in a cyclic graph, is not followed.
+//
+// Struct panics if value is not a struct or a pointer to one, or if a tag
+// is malformed or names an unknown rule.
+//
+// Example:
+//
+//	type Address struct {
+//	    Zip string `json:"zip" validate:"required,regex=^[0-9]{5}$"`
+//	}
+//	type User struct {
+//	    Name    string           `json:"name" validate:"required,max=64"`
+//	    Age     core.Option[int] `json:"age" validate:"min=18"`
+//	    Address Address          `json:"address"`
+//	}
+//	result := Struct(user)
+//	result.UnwrapErr().Error() // "name: is required\naddress.zip: must match ^[0-9]{5}$"
+func Struct[T any](value T, opts ...StructOption) core.Result[T] {
+	e := &engine{
+		custom:    make(map[string]func(value any) error),
+		ancestors: make(map[visit]struct{}),
+	}
+	for _, opt := range opts {
+		opt(e)
+	}
+	rv := reflect.ValueOf(value)
+	for rv.Kind() == reflect.Pointer && !rv.IsNil() {
+		e.enter(rv)
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		panic(fmt.Sprintf("validate: Struct needs a struct, got %T", value))
+	}
+	var errs []error
+	e.checkStruct(rv, "", &errs)
+	if len(errs) > 0 {
+		return internal.Err[T](&core.MultiError{Errors: errs})
+	}
+	return internal.Ok(value)
+}
+
+func (e *engine) checkStruct(rv reflect.Value, path string, errs *[]error) {
+	for _, field := range planOf(rv.Type()) {
+		e.checkField(rv.Field(field.index), field.rules, joinPath(path, field.name), errs)
+	}
+}
+
+func (e *engine) checkField(v reflect.Value, rules []tagRule, path string, errs *[]error) {
+	if isOption(v) {
+		inner, some := unwrapOption(v)
+		if !some {
+			if hasRule(rules, "required") {
+				*errs = append(*errs, &core.ValidationError{Path: path, Err: requiredError()})
+			}
+			return
+		}
+		v = inner
+		rules = withoutRule(rules, "required")
+	}
+	target := v
+	for target.Kind() == reflect.Pointer {
+		if target.IsNil() {
+			if hasRule(rules, "required") {
+				*errs = append(*errs, &core.ValidationError{Path: path, Err: requiredError()})
+			}
+			return
+		}
+		target = target.Elem()
+		rules = withoutRule(rules, "required")
+	}
+	for _, rule := range rules {
+		if err := e.apply(rule, target); err != nil {
+			*errs = append(*errs, &core.ValidationError{Path: path, Err: err})
+		}
+	}
+	// v keeps its pointers so that descend can stop at cycles
+	e.descend(v, path, errs)
+}
+
+func (e *engine) descend(v reflect.Value, path string, errs *[]error) {
+	if isOption(v) {
+		if inner, some := unwrapOption(v); some {
+			e.descend(inner, path, errs)
+		}
+		return
+	}
+	switch v.Kind() {
+	case reflect.Pointer:
+		if !v.IsNil() && e.enter(v) {
+			e.descend(v.Elem(), path, errs)
+			e.leave(v)
+		}
+	case reflect.Interface:
+		if !v.IsNil() {
+			e.descend(v.Elem(), path, errs)
+		}
+	case reflect.Struct:
+		e.checkStruct(v, path, errs)
+	case reflect.Slice, reflect.Array:
+		for i := range v.Len() {
+			e.descend(v.Index(i), path+"["+strconv.Itoa(i)+"]", errs)
+		}
+	}
+}
+
+// enter adds the pointer v to the current descent path and reports whether
+// it was not already on it.
+func (e *engine) enter(v reflect.Value) bool {
+	key := visit{ptr: v.Pointer(), typ: v.Type()}
+	if _, seen := e.ancestors[key]; seen {
+		return false
+	}
+	e.ancestors[key] = struct{}{}
+	return true
+}
+
+// leave removes the pointer v from the current descent path.
+func (e *engine) leave(v reflect.Value) {
+	delete(e.ancestors, visit{ptr: v.Pointer(), typ: v.Type()})
+}
+
+func (e *engine) apply(rule tagRule, v reflect.Value) error {
+	switch rule.name {
+	case "required":
+		if isEmpty(v) {
+			return requiredError()
+		}
+	case "min":
+		if compare(rule, v) < 0 {
+			if hasLength(v) {
+				return minLenError(rule.param)
+			}
+			return minError(rule.param)
+		}
+	case "max":
+		if compare(rule, v) > 0 {
+			if hasLength(v) {
+				return maxLenError(rule.param)
+			}
+			return maxError(rule.param)
+		}
+	case "len":
+		if !hasLength(v) {
+			panic(fmt.Sprintf("validate: rule len cannot check %s", v.Type()))
+		}
+		if length(v) != parseInt(rule) {
+			return lenError(rule.param)
+		}
+	case "regex":
+		if v.Kind() != reflect.String {
+			panic(fmt.Sprintf("validate: rule regex cannot check %s", v.Type()))
+		}
+		if !rule.re.MatchString(v.String()) {
+			return regexError(rule.param)
+		}
+	case "oneof":
+		if !oneOf(rule.param, fmt.Sprint(v.Interface())) {
+			return oneOfError(rule.param)
+		}
+	default:
+		fn, ok := e.custom[rule.name]
+		if !ok {
+			panic(fmt.Sprintf("validate: unknown rule %q", rule.name))
+		}
+		return fn(v.Interface())
+	}
+	return nil
+}
+
+func planOf(t reflect.Type) []fieldPlan {
+	if cached, ok := plans.Load(t); ok {
+		return cached.([]fieldPlan)
+	}
+	var fields []fieldPlan
+	for i := range t.NumField() {
+		field := t.Field(i)
+		tag := field.Tag.Get(tagName)
+		if !field.IsExported() || tag == "-" {
+			continue
+		}
+		fields = append(fields, fieldPlan{
+			index: i,
+			name:  fieldName(field),
+			rules: parseTag(tag),
+		})
+	}
+	plans.Store(t, fields)
+	return fields
+}
+
+func parseTag(tag string) []tagRule {
+	if tag == "" {
+		return nil
+	}
+	parts := strings.Split(tag, ",")
+	rules := make([]tagRule, 0, len(parts))
+	for _, part := range parts {
+		name, param, _ := strings.Cut(strings.TrimSpace(part), "=")
+		rule := tagRule{name: name, param: param}
+		switch name {
+		case "":
+			panic(fmt.Sprintf("validate: malformed tag %q", tag))
+		case "regex":
+			rule.re = regexp.MustCompile(param)
+		}
+		rules = append(rules, rule)
+	}
+	return rules
+}
+
+func fieldName(field reflect.StructField) string {
+	name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+	if name == "" || name == "-" {
+		return field.Name
+	}
+	return name
+}
+
+func isOption(v reflect.Value) bool {
+	return v.Kind() == reflect.Interface && v.Type().Implements(optionType)
+}
+
+func unwrapOption(v reflect.Value) (reflect.Value, bool) {
+	if v.IsNil() || !v.Interface().(optionLike).IsSome() {
+		return reflect.Value{}, false
+	}
+	return v.MethodByName("Unwrap").Call(nil)[0], true
+}
+
+func hasRule(rules []tagRule, name string) bool {
+	for _, rule := range rules {
+		if rule.name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func withoutRule(rules []tagRule, name string) []tagRule {
+	kept := make([]tagRule, 0, len(rules))
+	for _, rule := range rules {
+		if rule.name != name {
+			kept = append(kept, rule)
+		}
+	}
+	return kept
+}
+
+func isEmpty(v reflect.Value) bool {
+	if hasLength(v) {
+		return length(v) == 0
+	}
+	return v.IsZero()
+}
+
+func hasLength(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.String, reflect.Slice, reflect.Array, reflect.Map:
+		return true
+	default:
+		return false
+	}
+}
+
+func length(v reflect.Value) int {
+	if v.Kind() == reflect.String {
+		return utf8.RuneCountInString(v.String())
+	}
+	return v.Len()
+}
+
+// compare returns -1, 0 or +1 as v, or its length, is less than, equal to or
+// greater than the rule's parameter.
+func compare(rule tagRule, v reflect.Value) int {
+	if hasLength(v) {
+		return cmp.Compare(length(v), parseInt(rule))
+	}
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(rule.param, 10, 64)
+		mustParse(rule, err)
+		return cmp.Compare(v.Int(), n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		n, err := strconv.ParseUint(rule.param, 10, 64)
+		mustParse(rule, err)
+		return cmp.Compare(v.Uint(), n)
+	case reflect.Float32, reflect.Float64:
+		n, err := strconv.ParseFloat(rule.param, 64)
+		mustParse(rule, err)
+		return cmp.Compare(v.Float(), n)
+	default:
+		panic(fmt.Sprintf("validate: rule %s cannot check %s", rule.name, v.Type()))
+	}
+}
+
+func parseInt(rule tagRule) int {
+	n, err := strconv.Atoi(rule.param)
+	mustParse(rule, err)
+	return n
+}
+
+func mustParse(rule tagRule, err error) {
+	if err != nil {
+		panic(fmt.Sprintf("validate: rule %s has invalid parameter %q", rule.name, rule.param))
+	}
+}
+
+func oneOf(param, value string) bool {
+	for _, allowed := range strings.Fields(param) {
+		if allowed == value {
+			return true
+		}
+	}
+	return false
+}
+
+func joinPath(parent, child string) string {
+	if parent == "" {
+		return child
+	}
+	return parent + "." + child
+}
@@ -0,0 +1,256 @@
+package validate_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/shoenig/test/must"
+
+	"codeberg.org/yaadata/opt/core"
+	"codeberg.org/yaadata/opt/extension"
+	"codeberg.org/yaadata/opt/internal"
+	"codeberg.org/yaadata/opt/validate"
+)
+
+type address struct {
+	Zip string `json:"zip" validate:"required,regex=^[0-9]{5}$"`
+}
+
+type item struct {
+	SKU string `json:"sku" validate:"required,len=6"`
+	Qty int    `json:"qty" validate:"min=1,max=99"`
+}
+
+type user struct {
+	Name     string               `json:"name" validate:"required,max=8"`
+	Role     string               `json:"role" validate:"oneof=admin member"`
+	Email    core.Option[string]  `json:"email" validate:"required,regex=@"`
+	Age      core.Option[int]     `json:"age" validate:"min=18"`
+	Address  address              `json:"address"`
+	Billing  core.Option[address] `json:"billing"`
+	Items    []item               `json:"items" validate:"min=1"`
+	Manager  *user                `json:"manager"`
+	Tags     map[string]string    `validate:"max=2"`
+	Internal string               `validate:"-"`
+}
+
+type profile struct {
+	Nickname *string `json:"nickname" validate:"required,len=4,regex=^[a-z]+$"`
+	Age      *int    `json:"age" validate:"min=18"`
+}
+
+type route struct {
+	From *address `json:"from"`
+	To   *address `json:"to"`
+}
+
+type node struct {
+	Name string `json:"name" validate:"required"`
+	Next *node  `json:"next"`
+}
+
+func validUser() user {
+	return user{
+		Name:    "alice",
+		Role:    "admin",
+		Email:   internal.Some("alice@example.com"),
+		Age:     internal.None[int](),
+		Address: address{Zip: "12345"},
+		Billing: internal.None[address](),
+		Items:   []item{{SKU: "ABC123", Qty: 2}},
+	}
+}
+
+func violations[T any](t *testing.T, result core.Result[T]) []string {
+	t.Helper()
+	multi := extension.ErrorAs[*core.MultiError](result).Unwrap()
+	messages := make([]string, len(multi.Errors))
+	for i, err := range multi.Errors {
+		messages[i] = err.Error()
+	}
+	return messages
+}
+
+func TestStruct(t *testing.T) {
+	t.Parallel()
+	t.Run("Valid struct is Ok", func(t *testing.T) {
+		t.Parallel()
+		// [A]rrange
+		u := validUser()
+		// [A]ct
+		actual := validate.Struct(u)
+		// [A]ssert
+		must.Eq(t, "alice", actual.Unwrap().Name)
+	})
+
+	t.Run("Every violation is reported with its path", func(t *testing.T) {
+		t.Parallel()
+		// [A]rrange
+		u := validUser()
+		u.Name = ""
+		u.Role = "owner"
+		u.Address.Zip = "1234"
+		u.Items = []item{{SKU: "ABC123", Qty: 2}, {SKU: "AB", Qty: 0}}
+		u.Tags = map[string]string{"a": "", "b": "", "c": ""}
+		// [A]ct
+		actual := validate.Struct(u)
+		// [A]ssert
+		must.Eq(t, []string{
+			"name: is required",
+			"role: must be one of admin member",
+			"address.zip: must match ^[0-9]{5}$",
+			"items[1].sku: must have length 6",
+			"items[1].qty: must be at least 1",
+			"Tags: must have length at most 2",
+		}, violations(t, actual))
+	})
+
+	t.Run("Option fields", func(t *testing.T) {
+		t.Parallel()
+		// [A]rrange
+		u := validUser()
+		u.Email = internal.None[string]()
+		u.Age = internal.Some(16)
+		u.Billing = internal.Some(address{Zip: "x"})
+		// [A]ct
+		actual := validate.Struct(u)
+		// [A]ssert
+		must.Eq(t, []string{
+			"email: is required",
+			"age: must be at least 18",
+			"billing.zip: must match ^[0-9]{5}$",
+		}, violations(t, actual))
+	})
+
+	t.Run("Nil Option is None", func(t *testing.T) {
+		t.Parallel()
+		// [A]rrange
+		u := validUser()
+		u.Email = nil
+		u.Age = nil
+		// [A]ct
+		actual := validate.Struct(u)
+		// [A]ssert
+		must.Eq(t, []string{"email: is required"}, violations(t, actual))
+	})
+
+	t.Run("Pointer fields", func(t *testing.T) {
+		t.Parallel()
+		// [A]rrange
+		nickname, age := "AB", 16
+		valid, adult := "abcd", 30
+		// [A]ct
+		invalid := validate.Struct(profile{Nickname: &nickname, Age: &age})
+		absent := validate.Struct(profile{})
+		actual := validate.Struct(profile{Nickname: &valid, Age: &adult})
+		// [A]ssert
+		must.Eq(t, []string{
+			"nickname: must have length 4",
+			"nickname: must match ^[a-z]+$",
+			"age: must be at least 18",
+		}, violations(t, invalid))
+		must.Eq(t, []string{"nickname: is required"}, violations(t, absent))
+		must.True(t, actual.IsOk())
+	})
+
+	t.Run("Pointers to structs are followed", func(t *testing.T) {
+		t.Parallel()
+		// [A]rrange
+		manager := validUser()
+		manager.Name = "a very long name"
+		u := validUser()
+		u.Manager = &manager
+		// [A]ct
+		actual := validate.Struct(&u)
+		// [A]ssert
+		must.Eq(t, []string{"manager.name: must have length at most 8"}, violations(t, actual))
+	})
+
+	t.Run("Cyclic pointers are checked once", func(t *testing.T) {
+		t.Parallel()
+		// [A]rrange
+		head := &node{}
+		tail := &node{Name: "tail", Next: head}
+		head.Next = tail
+		self := &node{}
+		self.Next = self
+		// [A]ct
+		actual := validate.Struct(head)
+		selfActual := validate.Struct(self)
+		// [A]ssert
+		must.Eq(t, []string{"name: is required"}, violations(t, actual))
+		must.Eq(t, []string{"name: is required"}, violations(t, selfActual))
+	})
+
+	t.Run("Shared pointers are checked at every path", func(t *testing.T) {
+		t.Parallel()
+		// [A]rrange
+		shared := &address{Zip: "1234"}
+		// [A]ct
+		actual := validate.Struct(route{From: shared, To: shared})
+		// [A]ssert
+		must.Eq(t, []string{"from.zip: must match ^[0-9]{5}$", "to.zip: must match ^[0-9]{5}$"}, violations(t, actual))
+	})
+
+	t.Run("Violations wrap a RuleError", func(t *testing.T) {
+		t.Parallel()
+		// [A]rrange
+		u := validUser()
+		u.Items = nil
+		// [A]ct
+		actual := validate.Struct(u)
+		// [A]ssert
+		ruleErr := extension.ErrorAs[*validate.RuleError](actual).Unwrap()
+		must.Eq(t, "min", ruleErr.Rule)
+		must.Eq(t, "1", ruleErr.Param)
+		fieldErr := extension.ErrorAs[*core.ValidationError](actual).Unwrap()
+		must.Eq(t, "items", fieldErr.Path)
+	})
+}
+
+func TestStruct_WithRule(t *testing.T) {
+	t.Parallel()
+	type order struct {
+		SKU string `json:"sku" validate:"sku"`
+	}
+	errBadSKU := errors.New("must start with SKU-")
+	checkSKU := func(value any) error {
+		if !strings.HasPrefix(value.(string), "SKU-") {
+			return errBadSKU
+		}
+		return nil
+	}
+
+	t.Run("Custom rule is applied", func(t *testing.T) {
+		t.Parallel()
+		// [A]ct
+		actual := validate.Struct(order{SKU: "123"}, validate.WithRule("sku", checkSKU))
+		// [A]ssert
+		must.EqError(t, actual.UnwrapErr(), "sku: must start with SKU-")
+		must.ErrorIs(t, actual.UnwrapErr(), errBadSKU)
+	})
+
+	t.Run("Unknown rule panics", func(t *testing.T) {
+		t.Parallel()
+		// [A]ct
+		actual := extension.CatchResult(func() core.Result[order] {
+			return validate.Struct(order{SKU: "123"})
+		})
+		// [A]ssert
+		must.StrContains(t, actual.UnwrapErr().Error(), `unknown rule "sku"`)
+	})
+}
+
+func TestStruct_NotAStruct(t *testing.T) {
+	t.Parallel()
+	t.Run("Non-struct panics", func(t *testing.T) {
+		t.Parallel()
+		// [A]ct
+		actual := extension.CatchResult(func() core.Result[int] {
+			return validate.Struct(3)
+		})
+		// [A]ssert
+		must.StrContains(t, actual.UnwrapErr().Error(), "validate: Struct needs a struct, got int")
+	})
+}
@@ -0,0 +1,113 @@
+package optionsgo_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/shoenig/test/must"
+
+	. "codeberg.org/yaadata/opt"
+	"codeberg.org/yaadata/opt/core"
+	"codeberg.org/yaadata/opt/extension"
+)
+
+func TestValidated_Valid(t *testing.T) {
+	t.Parallel()
+	t.Run("IsValid is true", func(t *testing.T) {
+		t.Parallel()
+		// [A]rrange
+		v := Valid("12345")
+		// [A]ct
+		actual := v.IsValid()
+		// [A]ssert
+		must.True(t, actual)
+		must.False(t, v.IsInvalid())
+		must.SliceEmpty(t, v.Errors())
+	})
+
+	t.Run("Result is Ok", func(t *testing.T) {
+		t.Parallel()
+		// [A]rrange
+		v := Valid("12345")
+		// [A]ct
+		actual := v.Result()
+		// [A]ssert
+		must.Eq(t, "12345", actual.Unwrap())
+	})
+
+	t.Run("At is unchanged", func(t *testing.T) {
+		t.Parallel()
+		// [A]rrange
+		v := Valid("12345")
+		// [A]ct
+		actual := v.At("zip")
+		// [A]ssert
+		must.Eq(t, "12345", actual.UnwrapOr(""))
+	})
+}
+
+func TestValidated_Invalid(t *testing.T) {
+	t.Parallel()
+	t.Run("IsInvalid is true", func(t *testing.T) {
+		t.Parallel()
+		// [A]rrange
+		v := Invalid[string](errors.New("a"), errors.New("b"))
+		// [A]ct
+		actual := v.IsInvalid()
+		// [A]ssert
+		must.True(t, actual)
+		must.False(t, v.IsValid())
+		must.Len(t, 2, v.Errors())
+		must.Eq(t, "default", v.UnwrapOr("default"))
+	})
+
+	t.Run("Result is Err with a MultiError", func(t *testing.T) {
+		t.Parallel()
+		// [A]rrange
+		errA := errors.New("a")
+		errB := errors.New("b")
+		v := Invalid[string](errA, errB)
+		// [A]ct
+		actual := v.Result()
+		// [A]ssert
+		must.EqError(t, actual.UnwrapErr(), "a\nb")
+		must.ErrorIs(t, actual.UnwrapErr(), errA)
+		must.ErrorIs(t, actual.UnwrapErr(), errB)
+		multi := extension.ErrorAs[*core.MultiError](actual).Unwrap()
+		must.Eq(t, []error{errA, errB}, multi.Errors)
+	})
+
+	t.Run("At builds field paths", func(t *testing.T) {
+		t.Parallel()
+		// [A]rrange
+		cause := errors.New("must be 5 digits")
+		v := Invalid[string](cause)
+		// [A]ct
+		actual := v.At("zip").At("[0]").At("addresses")
+		// [A]ssert
+		must.EqError(t, actual.Result().UnwrapErr(), "addresses[0].zip: must be 5 digits")
+		must.ErrorIs(t, actual.Result().UnwrapErr(), cause)
+		fieldErr := extension.ErrorAs[*core.ValidationError](actual.Result()).Unwrap()
+		must.Eq(t, "addresses[0].zip", fieldErr.Path)
+	})
+
+	t.Run("MultiError errors are flattened", func(t *testing.T) {
+		t.Parallel()
+		// [A]rrange
+		inner := Invalid[string](errors.New("a"), errors.New("b")).Result().UnwrapErr()
+		// [A]ct
+		actual := Invalid[string](inner, errors.New("c"))
+		// [A]ssert
+		must.Len(t, 3, actual.Errors())
+	})
+
+	t.Run("No errors panics", func(t *testing.T) {
+		t.Parallel()
+		// [A]ct
+		actual := extension.Catch(func() Validated[string] {
+			return Invalid[string]()
+		})
+		// [A]ssert
+		must.True(t, actual.IsError())
+	})
+}
@@ -0,0 +1,128 @@
+package optionsgo_test
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"log/slog"
+	"testing"
+
+	"github.com/shoenig/test/must"
+
+	. "codeberg.org/yaadata/opt"
+)
+
+func TestWarned(t *testing.T) {
+	t.Parallel()
+	errDeprecated := errors.New("field is deprecated")
+	errTruncated := errors.New("record was truncated")
+
+	t.Run("Result drops warnings", func(t *testing.T) {
+		t.Parallel()
+		// [A]rrange
+		w := WithWarnings(Ok(3), errDeprecated)
+		// [A]ct
+		actual := w.Result()
+		// [A]ssert
+		must.Eq(t, 3, actual.Unwrap())
+		must.True(t, w.HasWarnings())
+	})
+
+	t.Run("Warn appends without changing the original", func(t *testing.T) {
+		t.Parallel()
+		// [A]rrange
+		w := WithWarnings(Ok(3), errDeprecated)
+		// [A]ct
+		actual := w.Warn(errTruncated)
+		// [A]ssert
+		must.Eq(t, []error{errDeprecated, errTruncated}, actual.Warnings())
+		must.Eq(t, []error{errDeprecated}, w.Warnings())
+	})
+
+	t.Run("Nil warnings are dropped", func(t *testing.T) {
+		t.Parallel()
+		// [A]rrange
+		var errNone error
+		w := WithWarnings(Ok(3), errNone)
+		// [A]ct
+		actual := w.Warn(errNone)
+		// [A]ssert
+		must.False(t, actual.HasWarnings())
+		must.SliceEmpty(t, actual.Warnings())
+		must.Eq(t, 3, actual.Escalate().Unwrap())
+	})
+
+	t.Run("Nil warnings are dropped among others", func(t *testing.T) {
+		t.Parallel()
+		// [A]rrange
+		warnings := []error{nil, errDeprecated, nil}
+		// [A]ct
+		actual := WithWarnings(Ok(3), warnings...)
+		// [A]ssert
+		must.Eq(t, []error{errDeprecated}, actual.Warnings())
+		must.Eq(t, []error{nil, errDeprecated, nil}, warnings)
+	})
+
+	t.Run("Escalate turns warnings into an Err", func(t *testing.T) {
+		t.Parallel()
+		// [A]rrange
+		w := WithWarnings(Ok(3), errDeprecated, errTruncated)
+		// [A]ct
+		actual := w.Escalate()
+		// [A]ssert
+		must.ErrorIs(t, actual.UnwrapErr(), errDeprecated)
+		must.ErrorIs(t, actual.UnwrapErr(), errTruncated)
+	})
+
+	t.Run("Escalate keeps the original Err first", func(t *testing.T) {
+		t.Parallel()
+		// [A]rrange
+		cause := errors.New("failed")
+		w := WithWarnings(Err[int](cause), errDeprecated)
+		// [A]ct
+		actual := w.Escalate()
+		// [A]ssert
+		must.EqError(t, actual.UnwrapErr(), "failed\nfield is deprecated")
+	})
+
+	t.Run("Escalate without warnings is unchanged", func(t *testing.T) {
+		t.Parallel()
+		// [A]rrange
+		w := WithWarnings(Ok(3))
+		// [A]ct
+		actual := w.Escalate()
+		// [A]ssert
+		must.False(t, w.HasWarnings())
+		must.Eq(t, 3, actual.Unwrap())
+	})
+
+	t.Run("Formatting shows warnings", func(t *testing.T) {
+		t.Parallel()
+		// [A]rrange
+		w := WithWarnings(Ok(3), errDeprecated, errTruncated)
+		// [A]ct
+		actual := fmt.Sprintf("%v", w)
+		// [A]ssert
+		must.Eq(t, "Ok(3) (warnings: field is deprecated; record was truncated)", actual)
+		must.Eq(t, "Ok(3)", fmt.Sprintf("%v", WithWarnings(Ok(3))))
+	})
+
+	t.Run("Logging records warnings", func(t *testing.T) {
+		t.Parallel()
+		// [A]rrange
+		var buf bytes.Buffer
+		logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{
+			ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+				if a.Key == slog.TimeKey {
+					return slog.Attr{}
+				}
+				return a
+			},
+		}))
+		w := WithWarnings(Ok(3), errTruncated)
+		// [A]ct
+		logger.Info("imported", "record", w)
+		// [A]ssert
+		must.Eq(t, "level=INFO msg=imported record.value=3 record.warnings=\"[record was truncated]\"\n", buf.String())
+	})
+}